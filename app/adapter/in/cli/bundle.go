@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	bundleExportCmd.Flags().String("url", "", "template url to export (default: the template of the current project)")
+	bundleCmd.AddCommand(bundleExportCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+	cmd.RootCmd.AddCommand(bundleCmd)
+}
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "move cached templates between machines without git access",
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export [bundle.tar.gz]",
+	Short: "export every cached tag of a template into a bundle",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBundleExportCmd,
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import [bundle.tar.gz]",
+	Short: "import a template bundle into the template cache",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBundleImportCmd,
+}
+
+func runBundleExportCmd(cmd *cobra.Command, args []string) error {
+	templateURL, _ := cmd.Flags().GetString("url")
+	if templateURL == "" {
+		config, err := utils.ReadEinarCli()
+		if err != nil {
+			return domain.Errorf(domain.ErrorCodeOf(err), "run bundle export inside your project or pass --url: %w", err)
+		}
+		templateURL = config.Template.URL
+	}
+
+	manifest, err := business.EinarBundleExport(cmd.Context(), templateURL, invocationPath(args[0]))
+	if err != nil {
+		return err
+	}
+	utils.Info("template tags exported", "tags", manifest.Tags, "template", manifest.URL, "bundle", args[0])
+	return nil
+}
+
+func runBundleImportCmd(cmd *cobra.Command, args []string) error {
+	_, err := business.EinarBundleImport(cmd.Context(), invocationPath(args[0]))
+	return err
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	installCmd.ValidArgsFunction = completeInstallations
+	generateCmd.ValidArgsFunction = completeComponentKinds
+	cmd.RootCmd.AddCommand(completionCmd)
+}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "generate the shell completion script of einar",
+	Long: `Generate the shell completion script of einar. For example, to load it in the current shell:
+
+  bash:       source <(einar completion bash)
+  zsh:        source <(einar completion zsh)
+  fish:       einar completion fish | source
+  powershell: einar completion powershell | Out-String | Invoke-Expression`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	RunE:      runCompletionCmd,
+}
+
+func runCompletionCmd(cmd *cobra.Command, args []string) error {
+	root := cmd.Root()
+	switch args[0] {
+	case "bash":
+		return root.GenBashCompletionV2(resultOutput, true)
+	case "zsh":
+		return root.GenZshCompletion(resultOutput)
+	case "fish":
+		return root.GenFishCompletion(resultOutput, true)
+	default:
+		return root.GenPowerShellCompletionWithDesc(resultOutput)
+	}
+}
+
+// completeInstallations completes the installations of the project templates that are not
+// installed yet.
+func completeInstallations(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, resolved, ok := completionTemplate(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, installation := range resolved.Installations {
+		if !config.IsInstalled(installation.Command.Name) {
+			completions = append(completions, installation.Command.Name+"\t"+resolved.Layers[installation.Layer].Template.String())
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeComponentKinds completes the component kinds whose dependencies are installed.
+// Component names are new, so they are not completed.
+func completeComponentKinds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	config, resolved, ok := completionTemplate(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []string
+	for _, component := range resolved.Components {
+		commands := business.GetInstallCommandWithHighestMatches(config, component.Commands)
+		if len(commands) > 0 && config.HasDependency(commands[0].DependsOn) {
+			completions = append(completions, component.Kind+"\t"+resolved.Layers[component.Layer].Template.String())
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionTemplate reads the .einar.cli.json file and the pinned templates of the project
+// cmd completes arguments for. Completing never writes nor fetches anything: the file is not
+// migrated and nothing is completed until the templates are cached. Messages are discarded,
+// since the shell reads completions from the standard output.
+func completionTemplate(cmd *cobra.Command) (domain.EinarCli, domain.ResolvedTemplate, bool) {
+	utils.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	defer utils.SetLogger(nil)
+
+	if err := enterProjectDir(cmd); err != nil {
+		return domain.EinarCli{}, domain.ResolvedTemplate{}, false
+	}
+	config, err := utils.PeekEinarCli()
+	if err != nil || config.Project == "${project}" {
+		return domain.EinarCli{}, domain.ResolvedTemplate{}, false
+	}
+	resolved, err := business.EinarCachedTemplateLayers(cmd.Context(), config)
+	if err != nil {
+		return domain.EinarCli{}, domain.ResolvedTemplate{}, false
+	}
+	return config, resolved, true
+}
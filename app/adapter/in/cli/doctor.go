@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "repair the issues that can be fixed safely")
+	addOutputFlag(doctorCmd)
+	cmd.RootCmd.AddCommand(doctorCmd)
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "detect drift between .einar.cli.json, main.go and the project files",
+	Args:  cobra.NoArgs,
+	RunE:  runDoctorCmd,
+}
+
+func runDoctorCmd(cmd *cobra.Command, args []string) error {
+	fix, _ := cmd.Flags().GetBool("fix")
+	report, err := business.EinarDoctor(cmd.Context(), fix)
+	if err != nil {
+		printJSONResult(cmd, report, err)
+		return err
+	}
+	unhealthy := unhealthyError(report, fix)
+	if printJSONReport(cmd, report, unhealthy) {
+		return unhealthy
+	}
+	if len(report.Issues) == 0 {
+		fmt.Fprintln(resultOutput, "no issues found")
+		return nil
+	}
+	for _, issue := range report.Issues {
+		status := ""
+		if issue.Fixed {
+			status = " (fixed)"
+		}
+		fmt.Fprintf(resultOutput, "[%s] %s%s\n", issue.Check, issue.Message, status)
+	}
+	return unhealthy
+}
+
+// unhealthyError reports the issues of report that are left unfixed, nil when there are none.
+func unhealthyError(report domain.DoctorReport, fix bool) error {
+	if report.IsHealthy() {
+		return nil
+	}
+	unfixed := 0
+	for _, issue := range report.Issues {
+		if !issue.Fixed {
+			unfixed++
+		}
+	}
+	if fix {
+		return domain.Errorf(domain.ErrorUnhealthy, "issues left unfixed: %d", unfixed)
+	}
+	return domain.Errorf(domain.ErrorUnhealthy, "issues found: %d, run einar doctor --fix to repair the ones that can be fixed safely", unfixed)
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	templateCmd.AddCommand(extractTemplateCmd)
+}
+
+var extractTemplateCmd = &cobra.Command{
+	Use:   "extract [output directory]",
+	Short: "extract a reusable template from the current einar project",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runExtractTemplateCmd,
+}
+
+func runExtractTemplateCmd(cmd *cobra.Command, args []string) error {
+	template, err := business.EinarExtractTemplate(cmd.Context(), invocationPath(args[0]))
+	if err != nil {
+		return err
+	}
+	utils.Info("template contents extracted", "base_folders", len(template.BaseTemplate.Folders),
+		"base_files", len(template.BaseTemplate.Files), "component_kinds", len(template.ComponentCommands))
+	return nil
+}
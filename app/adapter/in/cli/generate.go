@@ -1,9 +1,8 @@
 package cli
 
 import (
-	"fmt"
-
 	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
 	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
 	"github.com/Ignaciojeria/einar/app/shared/utils"
 
@@ -11,6 +10,7 @@ import (
 )
 
 func init() {
+	addOutputFlag(generateCmd)
 	cmd.RootCmd.AddCommand(generateCmd)
 }
 
@@ -18,25 +18,29 @@ var generateCmd = &cobra.Command{
 	Use:   "generate [component type] [component name]",
 	Short: "generate component. for example: einar generate subscription my-subscription",
 	Args:  cobra.ExactArgs(2), // Ensure exactly 2 arguments are provided
-	Run:   runGenerateCmd,
+	RunE:  runGenerateCmd,
 }
 
-func runGenerateCmd(cmd *cobra.Command, args []string) {
+func runGenerateCmd(cmd *cobra.Command, args []string) error {
 	componentKind := args[0]
 	componentName := args[1]
 	componentName = utils.ConvertStringCase(componentName, "kebab")
-	// Read the JSON config file
-	config, _ := utils.ReadEinarCli()
-	if config.Project == "${project}" {
-		fmt.Println("Run installation command only inside your project.")
-		return
+	changes, err := generateComponent(cmd, componentKind, componentName)
+	if printJSONResult(cmd, changes, err) || err != nil {
+		return err
 	}
-	if err := business.EinarGenerate(
+	utils.Info("component generated", "kind", componentKind, "name", componentName)
+	return nil
+}
+
+func generateComponent(cmd *cobra.Command, componentKind, componentName string) (domain.ChangeSet, error) {
+	config, err := readProjectConfig(cmd)
+	if err != nil {
+		return domain.ChangeSet{}, err
+	}
+	return business.EinarGenerate(
 		cmd.Context(),
 		config.Project,
 		componentKind,
-		componentName); err != nil {
-		return
-	}
-	fmt.Println("Generate command executed for:", componentKind, "with name:", componentName)
+		componentName)
 }
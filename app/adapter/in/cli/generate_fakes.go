@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd.RootCmd.AddCommand(generateFakesCmd)
+}
+
+var generateFakesCmd = &cobra.Command{
+	Use:   "generate-fakes",
+	Short: "generate test doubles for every port in app/domain/ports/in and app/domain/ports/out",
+	Args:  cobra.NoArgs,
+	RunE:  runGenerateFakesCmd,
+}
+
+func runGenerateFakesCmd(cmd *cobra.Command, args []string) error {
+	config, err := readProjectConfig(cmd)
+	if err != nil {
+		return err
+	}
+	return business.EinarGenerateFakes(cmd.Context(), config.Project)
+}
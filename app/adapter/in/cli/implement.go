@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	implementCmd.Flags().String("kind", "http-client", "component kind of the project templates to generate the adapter as, e.g. http-client, repository or publisher")
+	cmd.RootCmd.AddCommand(implementCmd)
+}
+
+var implementCmd = &cobra.Command{
+	Use:   "implement [port file] [port type]",
+	Short: "implement a domain port. for example: einar implement app/domain/ports/out/shutdown.go Shutdown --kind http-client",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runImplementCmd,
+}
+
+func runImplementCmd(cmd *cobra.Command, args []string) error {
+	kind, _ := cmd.Flags().GetString("kind")
+	config, err := readProjectConfig(cmd)
+	if err != nil {
+		return err
+	}
+	return business.EinarImplement(cmd.Context(), config.Project, projectPath(args[0]), args[1], kind)
+}
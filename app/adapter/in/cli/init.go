@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Ignaciojeria/einar/app/business"
 	"github.com/Ignaciojeria/einar/app/domain"
@@ -12,6 +14,8 @@ import (
 )
 
 func init() {
+	initCmd.Flags().String("ref", "", "branch name or commit SHA of the template to use instead of its latest tag")
+	addOutputFlag(initCmd)
 	cmd.RootCmd.AddCommand(initCmd)
 }
 
@@ -19,15 +23,19 @@ func init() {
 var initCmd = &cobra.Command{
 	Use:   "init [project name] [repository template]",
 	Short: "Initialize a new Go module",
-	Run:   runInitCmd,
+	RunE:  runInitCmd,
 }
 
-func runInitCmd(cmd *cobra.Command, args []string) {
-	_, err := utils.ReadEinarCli()
+func runInitCmd(cmd *cobra.Command, args []string) error {
+	changes, err := initProject(cmd, args)
+	printJSONResult(cmd, changes, err)
+	return err
+}
 
-	if err == nil {
-		fmt.Println("einar cli already initialized")
-		return
+func initProject(cmd *cobra.Command, args []string) (domain.ChangeSet, error) {
+	var changes domain.ChangeSet
+	if _, err := utils.FS.Stat(".einar.cli.json"); err == nil {
+		return changes, domain.Errorf(domain.ErrorAlreadyExists, "einar cli already initialized")
 	}
 
 	var repositoryURL string
@@ -46,19 +54,27 @@ func runInitCmd(cmd *cobra.Command, args []string) {
 	}
 
 	if invalidArgsQuantity {
-		fmt.Println("accept 1 or 3 args only")
-		return
+		return changes, errors.New("accept 1 or 3 args only")
+	}
+
+	if utils.IsArchive(repositoryURL) && !strings.Contains(repositoryURL, "://") {
+		repositoryURL = invocationPath(repositoryURL)
 	}
 
-	templatePath, err := utils.GitCloneTemplateInBinaryPath(repositoryURL, userCredentials, "")
+	ref, _ := cmd.Flags().GetString("ref")
+	template := domain.Template{URL: repositoryURL, Ref: ref}
+	templatePath, err := utils.FetchTemplate(template, userCredentials)
 	if err != nil {
-		fmt.Println("error getting template path")
-		return
+		return changes, domain.Errorf(domain.ErrorTemplateNotFound, "error getting template path: %w", err)
 	}
 	tag, err := utils.GetLatestTag(templatePath)
 	if err != nil {
-		fmt.Println("error getting tag from templateURL")
-		return
+		return changes, domain.Errorf(domain.ErrorTemplateNotFound, "error getting tag from templateURL: %w", err)
+	}
+	if ref != "" {
+		template.Commit = tag
+	} else {
+		template.Tag = tag
 	}
 
 	project := args[0]
@@ -66,18 +82,22 @@ func runInitCmd(cmd *cobra.Command, args []string) {
 		project, _ = utils.GetCurrentFolderName()
 	}
 	project = utils.ConvertStringCase(project, "kebab")
-	business.EinarInit(cmd.Context(), templatePath, project)
+	changes, initErr := business.EinarInit(cmd.Context(), templatePath, project)
+
+	if template.IsMovingRef() {
+		warning := fmt.Sprintf("%s is pinned to branch %s at commit %s, pass a commit SHA to --ref to pin it for good",
+			template.URL, template.Ref, template.Commit)
+		changes.Warnings = append(changes.Warnings, warning)
+		utils.Warn(warning)
+	}
 
 	err = utils.CreateEinarCLIJSON(domain.EinarCli{
-		Project: args[0],
-		Template: domain.Template{
-			URL: repositoryURL,
-			Tag: tag,
-		},
+		Project:  args[0],
+		Template: template,
 	})
-
 	if err != nil {
-		fmt.Println("error creating einar cli file")
-		return
+		return changes, fmt.Errorf("error creating einar cli file: %w", err)
 	}
+	changes.Files = append(changes.Files, ".einar.cli.json")
+	return changes, initErr
 }
@@ -1,15 +1,14 @@
 package cli
 
 import (
-	"fmt"
-
 	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
 	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
-	"github.com/Ignaciojeria/einar/app/shared/utils"
 	"github.com/spf13/cobra"
 )
 
 func init() {
+	addOutputFlag(installCmd)
 	cmd.RootCmd.AddCommand(installCmd)
 }
 
@@ -18,23 +17,24 @@ var installCmd = &cobra.Command{
 	Short: "Install command for Einar",
 	Long:  `This command allows you to install various components.`,
 	Args:  cobra.ExactArgs(1),
-	Run:   runinstall,
+	RunE:  runinstall,
 }
 
-func runinstall(cmd *cobra.Command, args []string) {
-	config, _ := utils.ReadEinarCli()
-	if config.Project == "${project}" {
-		fmt.Println("Run installation command only inside your project.")
-		return
-	}
+func runinstall(cmd *cobra.Command, args []string) error {
+	changes, err := install(cmd, args[0])
+	printJSONResult(cmd, changes, err)
+	return err
+}
 
-	if config.IsInstalled(args[0]) {
-		fmt.Println("installation " + args[0] + " already added")
-		return
+func install(cmd *cobra.Command, installation string) (domain.ChangeSet, error) {
+	config, err := readProjectConfig(cmd)
+	if err != nil {
+		return domain.ChangeSet{}, err
 	}
 
-	if err := business.EinarInstall(cmd.Context(), config.Project, args[0]); err != nil {
-		fmt.Println(err.Error())
-		return
+	if config.IsInstalled(installation) {
+		return domain.ChangeSet{}, domain.Errorf(domain.ErrorAlreadyExists, "installation %s already added", installation)
 	}
+
+	return business.EinarInstall(cmd.Context(), config.Project, installation)
 }
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addOutputFlag(listCmd)
+	cmd.RootCmd.AddCommand(listCmd)
+}
+
+var listCmd = &cobra.Command{
+	Use:       "list [kinds|installations]",
+	Short:     "list the component kinds or installations available in the project templates",
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"kinds", "installations"},
+	RunE:      runListCmd,
+}
+
+// listEntry is a component kind or installation of the project templates.
+type listEntry struct {
+	Name       string   `json:"name"`
+	Template   string   `json:"template"`
+	Overrides  []string `json:"overrides,omitempty"`
+	Overridden []string `json:"overridden,omitempty"`
+}
+
+func runListCmd(cmd *cobra.Command, args []string) error {
+	resolved, err := business.EinarTemplateLayers(cmd.Context())
+	if err != nil {
+		printJSONResult(cmd, nil, err)
+		return err
+	}
+
+	var entries []listEntry
+	switch args[0] {
+	case "kinds":
+		for _, component := range resolved.Components {
+			var sourceFiles []string
+			for _, command := range component.Commands {
+				for _, file := range command.ComponentFiles {
+					sourceFiles = append(sourceFiles, file.SourceFile, file.Port.SourceFile)
+				}
+				for _, injection := range command.Injections {
+					sourceFiles = append(sourceFiles, injection.SourceFile)
+				}
+			}
+			entries = append(entries, listEntry{
+				Name:       component.Kind,
+				Template:   resolved.Layers[component.Layer].Template.String(),
+				Overrides:  shadowedLayers(resolved, component.Shadowed),
+				Overridden: overriddenFiles(resolved, component.Layer, sourceFiles),
+			})
+		}
+	case "installations":
+		for _, installation := range resolved.Installations {
+			var sourceFiles []string
+			for _, folder := range installation.Command.Folders {
+				sourceFiles = append(sourceFiles, resolved.OverridesIn(installation.Layer, folder.SourceDir)...)
+			}
+			if installation.Command.SourceDir != "" {
+				sourceFiles = append(sourceFiles, resolved.OverridesIn(installation.Layer, installation.Command.SourceDir)...)
+			}
+			for _, file := range installation.Command.Files {
+				sourceFiles = append(sourceFiles, file.SourceFile)
+			}
+			for _, injection := range installation.Command.Injections {
+				sourceFiles = append(sourceFiles, injection.SourceFile)
+			}
+			entries = append(entries, listEntry{
+				Name:       installation.Command.Name,
+				Template:   resolved.Layers[installation.Layer].Template.String(),
+				Overrides:  shadowedLayers(resolved, installation.Shadowed),
+				Overridden: overriddenFiles(resolved, installation.Layer, sourceFiles),
+			})
+		}
+	}
+
+	if printJSONResult(cmd, entries, nil) {
+		return nil
+	}
+	for _, entry := range entries {
+		line := entry.Name + "\t" + entry.Template
+		if len(entry.Overrides) > 0 {
+			line += "\t(collision: overrides " + strings.Join(entry.Overrides, ", ") + ")"
+		}
+		if len(entry.Overridden) > 0 {
+			line += "\t(overridden: " + strings.Join(entry.Overridden, ", ") + ")"
+		}
+		fmt.Fprintln(resultOutput, line)
+	}
+	return nil
+}
+
+func shadowedLayers(resolved domain.ResolvedTemplate, shadowed []int) []string {
+	var names []string
+	for _, layer := range shadowed {
+		names = append(names, resolved.Layers[layer].Template.String())
+	}
+	return names
+}
+
+func overriddenFiles(resolved domain.ResolvedTemplate, layer int, sourceFiles []string) []string {
+	var overridden []string
+	seen := make(map[string]bool)
+	for _, sourceFile := range sourceFiles {
+		if sourceFile == "" || !resolved.IsOverridden(layer, sourceFile) {
+			continue
+		}
+		key := resolved.OverrideKey(layer, sourceFile)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		overridden = append(overridden, key)
+	}
+	return overridden
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+	archetypeslog "github.com/Ignaciojeria/einar/app/shared/archetype/slog"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+func init() {
+	flags := cmd.RootCmd.PersistentFlags()
+	flags.BoolP("verbose", "v", false, "log debug messages and the progress of git and go commands")
+	flags.BoolP("quiet", "q", false, "log warnings and errors only")
+	flags.String("log-format", logFormatText, "log format: text or json")
+	cmd.RootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
+}
+
+// beginLogging sends the messages of einar and of the archetype to a logger on standard
+// error, leveled by --verbose and --quiet and formatted by --log-format.
+func beginLogging(cmd *cobra.Command) error {
+	level := slog.LevelInfo
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
+		level = slog.LevelDebug
+	}
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		level = slog.LevelWarn
+	}
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch format, _ := cmd.Flags().GetString("log-format"); format {
+	case logFormatText:
+		// people read text logs as they are written, the time only gets in the way
+		options.ReplaceAttr = func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		}
+		handler = slog.NewTextHandler(os.Stderr, options)
+	case logFormatJSON:
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	default:
+		return fmt.Errorf("invalid --log-format %q, expected %s or %s", format, logFormatText, logFormatJSON)
+	}
+
+	logger := slog.New(handler)
+	utils.SetLogger(logger)
+	archetypeslog.Logger = logger
+	return nil
+}
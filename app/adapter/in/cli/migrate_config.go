@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd.RootCmd.AddCommand(migrateConfigCmd)
+}
+
+var migrateConfigCmd = &cobra.Command{
+	Use:   "migrate-config",
+	Short: "upgrade .einar.cli.json to the current schema version",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateConfigCmd,
+}
+
+func runMigrateConfigCmd(cmd *cobra.Command, args []string) error {
+	migration, err := business.EinarMigrateConfig(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if !migration.Migrated() {
+		utils.Info("configuration already up to date", "file", ".einar.cli.json", "schema_version", migration.To)
+		return nil
+	}
+	utils.Info("configuration migrated", "file", ".einar.cli.json", "from", migration.From, "to", migration.To, "backup", migration.Backup)
+	return nil
+}
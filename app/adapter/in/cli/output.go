@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// resultOutput receives the command results and is the only writer of standard output.
+// Logs and the progress of git and go commands are written to standard error, so in JSON
+// mode standard output holds the JSON document alone.
+var resultOutput io.Writer = os.Stdout
+
+// commandOutput is the document written by commands run with --output json.
+type commandOutput struct {
+	Command string       `json:"command"`
+	OK      bool         `json:"ok"`
+	Result  interface{}  `json:"result,omitempty"`
+	Error   *outputError `json:"error,omitempty"`
+}
+
+type outputError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func addOutputFlag(commands ...*cobra.Command) {
+	for _, command := range commands {
+		command.Flags().StringP("output", "o", outputText, "output format: text or json")
+	}
+}
+
+// beginOutput validates --output and, in JSON mode, leaves reporting errors to
+// printJSONResult.
+func beginOutput(cmd *cobra.Command) error {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return nil
+	}
+	switch format {
+	case outputText:
+		return nil
+	case outputJSON:
+		cmd.SilenceErrors = true
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q, expected %s or %s", format, outputText, outputJSON)
+	}
+}
+
+// printJSONResult writes result, or err instead of it, as JSON when --output json is set
+// and reports whether it did. Text output is left to the caller.
+func printJSONResult(cmd *cobra.Command, result interface{}, err error) bool {
+	if format, _ := cmd.Flags().GetString("output"); format != outputJSON {
+		return false
+	}
+	if err != nil {
+		result = nil
+	}
+	writeJSONOutput(cmd, result, err)
+	return true
+}
+
+// printJSONReport is printJSONResult for commands whose result explains err, such as the
+// issues einar doctor leaves unfixed: the result is written next to the error.
+func printJSONReport(cmd *cobra.Command, result interface{}, err error) bool {
+	if format, _ := cmd.Flags().GetString("output"); format != outputJSON {
+		return false
+	}
+	writeJSONOutput(cmd, result, err)
+	return true
+}
+
+func writeJSONOutput(cmd *cobra.Command, result interface{}, err error) {
+	output := commandOutput{Command: cmd.Name(), OK: err == nil, Result: result}
+	if err != nil {
+		output.Error = &outputError{Code: string(domain.ErrorCodeOf(err)), Message: err.Error()}
+	}
+	encoder := json.NewEncoder(resultOutput)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(output)
+}
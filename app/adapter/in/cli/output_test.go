@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestJSONOutput(t *testing.T) {
+	var output bytes.Buffer
+	previous := resultOutput
+	resultOutput = &output
+	t.Cleanup(func() { resultOutput = previous })
+	stdout := os.Stdout
+
+	command := &cobra.Command{Use: "version"}
+	addOutputFlag(command)
+	if err := command.Flags().Set("output", outputJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := beginOutput(command); err != nil {
+		t.Fatalf("beginOutput() error = %v", err)
+	}
+	if os.Stdout != stdout {
+		t.Errorf("beginOutput() replaced os.Stdout, want it left alone")
+	}
+	if !command.SilenceErrors {
+		t.Errorf("beginOutput() left the errors to cobra, want them reported as JSON")
+	}
+
+	if !printJSONResult(command, map[string]string{"version": "1.0.0"}, errors.New("boom")) {
+		t.Fatal("printJSONResult() = false, want the result written as JSON")
+	}
+	var got commandOutput
+	if err := json.Unmarshal(output.Bytes(), &got); err != nil {
+		t.Fatalf("output = %q, want a JSON document: %v", output.String(), err)
+	}
+	if got.Command != "version" || got.OK || got.Result != nil || got.Error == nil || got.Error.Message != "boom" {
+		t.Errorf("output = %+v, want the error alone", got)
+	}
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func init() {
+	// Arguments that are not einar commands reach runPluginCmd untouched, flags included,
+	// so they can be handed to the plugin.
+	cmd.RootCmd.Args = cobra.ArbitraryArgs
+	cmd.RootCmd.DisableFlagParsing = true
+	cmd.RootCmd.RunE = runPluginCmd
+	cmd.RootCmd.ValidArgsFunction = completePlugins
+
+	addOutputFlag(pluginListCmd)
+	pluginCmd.AddCommand(pluginListCmd)
+	cmd.RootCmd.AddCommand(pluginCmd)
+}
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "inspect the plugin commands found on PATH",
+	Long: `Any executable called einar-<name> found on PATH runs as the einar <name> command. It receives
+the remaining arguments and, inside a project, these environment variables:
+
+  EINAR_PROJECT_ROOT   the project root
+  EINAR_CLI_JSON       the parsed .einar.cli.json
+  EINAR_TEMPLATE_PATH  the template cache folders of the project, base layer first`,
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the plugin commands found on PATH",
+	Args:  cobra.NoArgs,
+	RunE:  runPluginListCmd,
+}
+
+// runPlugin starts the plugin at path, tests replace it to keep the test process alive.
+var runPlugin = execPlugin
+
+// runPluginCmd runs the plugin named by the first argument, or shows the help when there is
+// none. The einar flags given before the plugin name apply to einar itself.
+func runPluginCmd(cmd *cobra.Command, args []string) error {
+	args, err := parseLeadingFlags(cmd, args)
+	if err != nil {
+		return err
+	}
+	if help, _ := cmd.Flags().GetBool("help"); help || len(args) == 0 {
+		return cmd.Help()
+	}
+	// the flags were not parsed yet when the command was prepared
+	if err := prepareCommand(cmd, args); err != nil {
+		return err
+	}
+	path, err := exec.LookPath(domain.PluginPrefix + args[0])
+	if err != nil {
+		return unknownCommandError(cmd, args[0])
+	}
+	dir := invocationDir
+	if projectDir, _ := cmd.Flags().GetString("project-dir"); projectDir != "" {
+		dir = invocationPath(projectDir)
+	}
+	env, err := business.EinarPluginEnv(cmd.Context(), dir)
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	return runPlugin(path, args[1:], append(os.Environ(), env...))
+}
+
+// parseLeadingFlags parses the flags of cmd found before the plugin name, which are left in
+// args since the root command does not parse flags, and returns the arguments after them.
+func parseLeadingFlags(cmd *cobra.Command, args []string) ([]string, error) {
+	flags := cmd.Flags()
+	flags.AddFlagSet(cmd.PersistentFlags())
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") && args[0] != "-" {
+		arg := args[0]
+		args = args[1:]
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(name)
+		} else if len(name) == 1 {
+			flag = flags.ShorthandLookup(name)
+		}
+		if flag == nil {
+			return nil, fmt.Errorf("unknown flag: %s\nRun '%s --help' for usage", arg, cmd.CommandPath())
+		}
+		if !hasValue {
+			switch {
+			case flag.NoOptDefVal != "":
+				value = flag.NoOptDefVal
+			case len(args) == 0:
+				return nil, fmt.Errorf("flag needs an argument: %s", arg)
+			default:
+				value, args = args[0], args[1:]
+			}
+		}
+		if err := flags.Set(flag.Name, value); err != nil {
+			return nil, fmt.Errorf("invalid argument %q for %s flag: %v", value, arg, err)
+		}
+	}
+	return args, nil
+}
+
+// unknownCommandError reports name like cobra does for unknown commands.
+func unknownCommandError(cmd *cobra.Command, name string) error {
+	message := fmt.Sprintf("unknown command %q for %q", name, cmd.CommandPath())
+	if cmd.SuggestionsMinimumDistance <= 0 {
+		cmd.SuggestionsMinimumDistance = 2
+	}
+	if suggestions := cmd.SuggestionsFor(name); len(suggestions) > 0 {
+		message += "\n\nDid you mean this?\n\t" + strings.Join(suggestions, "\n\t") + "\n"
+	}
+	return fmt.Errorf("%s\nRun '%s --help' for usage", message, cmd.CommandPath())
+}
+
+func runPluginListCmd(cmd *cobra.Command, args []string) error {
+	plugins, err := business.EinarPlugins(cmd.Context())
+	if err == nil {
+		plugins = shadowBuiltinCommands(cmd.Root(), plugins)
+	}
+	if printJSONResult(cmd, plugins, err) || err != nil {
+		return err
+	}
+	if len(plugins) == 0 {
+		fmt.Fprintln(resultOutput, "no plugins found on PATH")
+		return nil
+	}
+	for _, plugin := range plugins {
+		line := plugin.Name + "\t" + plugin.Path
+		if plugin.ShadowedBy != "" {
+			line += "\t(shadowed by " + plugin.ShadowedBy + ")"
+		}
+		fmt.Fprintln(resultOutput, line)
+	}
+	return nil
+}
+
+// shadowBuiltinCommands marks the plugins named like a command of root, which always wins.
+func shadowBuiltinCommands(root *cobra.Command, plugins []domain.Plugin) []domain.Plugin {
+	for i, plugin := range plugins {
+		if command, _, err := root.Find([]string{plugin.Name}); err == nil && command != root {
+			plugins[i].ShadowedBy = "built-in command " + command.CommandPath()
+		}
+	}
+	return plugins
+}
+
+// completePlugins completes the plugin commands next to the built-in ones.
+func completePlugins(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	plugins, _ := business.EinarPlugins(cmd.Context())
+	var completions []string
+	for _, plugin := range shadowBuiltinCommands(cmd.Root(), plugins) {
+		if plugin.ShadowedBy == "" {
+			completions = append(completions, plugin.Name+"\tplugin "+plugin.Path)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package cli
+
+import "syscall"
+
+// execPlugin replaces einar with the plugin at path.
+func execPlugin(path string, args, env []string) error {
+	return syscall.Exec(path, append([]string{path}, args...), env)
+}
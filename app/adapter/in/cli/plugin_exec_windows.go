@@ -0,0 +1,27 @@
+//go:build windows
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// execPlugin runs the plugin at path and exits with its exit code, since Windows cannot
+// replace the running process.
+func execPlugin(path string, args, env []string) error {
+	plugin := exec.Command(path, args...)
+	plugin.Env = env
+	plugin.Stdin, plugin.Stdout, plugin.Stderr = os.Stdin, resultOutput, os.Stderr
+	err := plugin.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+	if err != nil {
+		return err
+	}
+	os.Exit(0)
+	return nil
+}
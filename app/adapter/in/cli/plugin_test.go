@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func TestRunPluginCmd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are found by their .exe name on windows")
+	}
+	pathDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(pathDir, "einar-hello"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", pathDir)
+	projectDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		wantDir  string
+		wantErr  string
+		verbose  bool
+	}{
+		{
+			name:     "plugin",
+			args:     []string{"hello", "--name", "x"},
+			wantArgs: []string{"--name", "x"},
+			wantDir:  invocationDir,
+		},
+		{
+			name:     "verbose before the plugin",
+			args:     []string{"--verbose", "hello", "-v"},
+			wantArgs: []string{"-v"},
+			wantDir:  invocationDir,
+			verbose:  true,
+		},
+		{
+			name:     "project dir before the plugin",
+			args:     []string{"--project-dir", projectDir, "hello"},
+			wantArgs: []string{},
+			wantDir:  projectDir,
+		},
+		{
+			name:    "verbose before an unknown command",
+			args:    []string{"--verbose", "nosuch"},
+			wantErr: `unknown command "nosuch"`,
+		},
+		{
+			name:    "unknown flag before the plugin",
+			args:    []string{"--nosuch", "hello"},
+			wantErr: "unknown flag: --nosuch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotArgs []string
+			var gotDir string
+			runPlugin = func(path string, args, env []string) error {
+				gotArgs = args
+				gotDir, _ = os.Getwd()
+				return nil
+			}
+			t.Cleanup(func() {
+				runPlugin = execPlugin
+				os.Chdir(invocationDir)
+				cmd.RootCmd.PersistentFlags().Set("verbose", "false")
+				cmd.RootCmd.PersistentFlags().Set("project-dir", "")
+				utils.SetLogger(nil)
+			})
+
+			cmd.RootCmd.SetArgs(tt.args)
+			err := cmd.RootCmd.Execute()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if strings.Join(gotArgs, " ") != strings.Join(tt.wantArgs, " ") || gotDir != tt.wantDir {
+				t.Errorf("plugin ran with %q in %s, want %q in %s", gotArgs, gotDir, tt.wantArgs, tt.wantDir)
+			}
+			if debug := utils.LogWriter() == os.Stderr; debug != tt.verbose {
+				t.Errorf("debug logging = %v, want %v", debug, tt.verbose)
+			}
+		})
+	}
+}
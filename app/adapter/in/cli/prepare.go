@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd.RootCmd.PersistentPreRunE = prepareCommand
+}
+
+// prepareCommand runs before every command, once its flags and arguments are validated,
+// so the errors returned from here on are not usage errors.
+func prepareCommand(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	if err := beginLogging(cmd); err != nil {
+		return err
+	}
+	if err := beginOutput(cmd); err != nil {
+		return err
+	}
+	if err := enterProjectDir(cmd); err != nil {
+		printJSONResult(cmd, nil, err)
+		return err
+	}
+	return nil
+}
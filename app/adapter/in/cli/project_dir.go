@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// invocationDir is the working directory einar was started in, before entering the project.
+var invocationDir, _ = os.Getwd()
+
+// projectCmds run from the root of the project, the nearest folder above the working
+// directory, or above --project-dir, holding a .einar.cli.json file.
+var projectCmds = map[*cobra.Command]bool{
+	bundleExportCmd:    true,
+	doctorCmd:          true,
+	extractTemplateCmd: true,
+	generateCmd:        true,
+	generateFakesCmd:   true,
+	implementCmd:       true,
+	installCmd:         true,
+	listCmd:            true,
+	migrateConfigCmd:   true,
+	statusCmd:          true,
+}
+
+func init() {
+	cmd.RootCmd.PersistentFlags().String("project-dir", "", "run as if einar was started in this directory")
+}
+
+// enterProjectDir changes the working directory to --project-dir and, for project
+// commands, to the project root.
+func enterProjectDir(cmd *cobra.Command) error {
+	projectDir, _ := cmd.Flags().GetString("project-dir")
+	if projectDir != "" {
+		if cmd == initCmd {
+			if err := os.MkdirAll(projectDir, os.ModePerm); err != nil {
+				return err
+			}
+		}
+		if err := os.Chdir(projectDir); err != nil {
+			return fmt.Errorf("invalid --project-dir: %w", err)
+		}
+	}
+	if !projectCmds[cmd] {
+		return nil
+	}
+	if root, ok := utils.FindUp(".", ".einar.cli.json"); ok {
+		return os.Chdir(root)
+	}
+	return nil
+}
+
+// readProjectConfig reads the .einar.cli.json file of the project cmd runs in. Template
+// repositories carry it too, with a placeholder project, and are rejected.
+func readProjectConfig(cmd *cobra.Command) (domain.EinarCli, error) {
+	config, err := utils.ReadEinarCli()
+	if domain.ErrorCodeOf(err) == domain.ErrorNotInitialized || err == nil && config.Project == "${project}" {
+		return config, domain.Errorf(domain.ErrorNotInitialized, "run %s command only inside your project", cmd.Name())
+	}
+	return config, err
+}
+
+// invocationPath resolves path, given on the command line, against the directory einar
+// was started in.
+func invocationPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(invocationDir, path)
+}
+
+// projectPath resolves path, given on the command line, to a path relative to the project
+// root. Paths not found from the invocation directory are taken as project relative.
+func projectPath(path string) string {
+	if _, err := os.Stat(invocationPath(path)); err != nil {
+		return path
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, invocationPath(path)); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
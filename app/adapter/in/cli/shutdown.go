@@ -14,9 +14,9 @@ func init() {
 var shutdownCmd = &cobra.Command{
 	Use:   "shutdown",
 	Short: "short description of your command",
-	Run:   runshutdown,
+	RunE:  runshutdown,
 }
 
-func runshutdown(cmd *cobra.Command, args []string) {
-	client.Shutdown(cmd.Context())
+func runshutdown(cmd *cobra.Command, args []string) error {
+	return client.Shutdown(cmd.Context())
 }
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	statusCmd.Flags().Bool("all", false, "also list the generated files left pristine")
+	cmd.RootCmd.AddCommand(statusCmd)
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show the generated files that were modified or deleted",
+	Args:  cobra.NoArgs,
+	RunE:  runStatusCmd,
+}
+
+func runStatusCmd(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	statuses, err := business.EinarStatus(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	pristine := 0
+	for _, status := range statuses {
+		if status.Status == domain.FilePristine {
+			pristine++
+			if !all {
+				continue
+			}
+		}
+		fmt.Fprintf(resultOutput, "%-9s %s (%s)\n", status.Status, status.Path, status.Origin)
+	}
+	fmt.Fprintf(resultOutput, "%d of %d generated files pristine\n", pristine, len(statuses))
+	return nil
+}
@@ -0,0 +1,16 @@
+package cli
+
+import (
+	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	cmd.RootCmd.AddCommand(templateCmd)
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "commands for einar template authors",
+}
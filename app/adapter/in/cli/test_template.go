@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/business"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	templateCmd.AddCommand(testTemplateCmd)
+}
+
+var testTemplateCmd = &cobra.Command{
+	Use:   "test [template directory]",
+	Short: "init, install and generate every kind of a template in scratch projects and build them",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTestTemplateCmd,
+}
+
+func runTestTemplateCmd(cmd *cobra.Command, args []string) error {
+	report, err := business.EinarTestTemplate(cmd.Context(), invocationPath(args[0]))
+	if err != nil {
+		return err
+	}
+
+	for _, scenario := range report.Scenarios {
+		status := "ok"
+		if len(scenario.Failures) > 0 {
+			status = "FAIL"
+		}
+		fmt.Fprintf(resultOutput, "%s\tinstallations: [%s] kinds: [%s]\n", status,
+			strings.Join(scenario.Installations, ", "), strings.Join(scenario.Kinds, ", "))
+		for _, failure := range scenario.Failures {
+			fmt.Fprintf(resultOutput, "\t%s %s failed:\n%s\n", failure.Step, failure.Target, failure.Output)
+		}
+	}
+	if !report.Passed() {
+		return errors.New("template test failed")
+	}
+	return nil
+}
@@ -9,15 +9,22 @@ import (
 )
 
 func init() {
+	addOutputFlag(versionCmd)
 	cmd.RootCmd.AddCommand(versionCmd)
 }
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "retrieve einar cli version",
-	Run:   runversion,
+	RunE:  runVersionCmd,
 }
 
-func runversion(cmd *cobra.Command, args []string) {
-	fmt.Println("1.47.0")
+const version = "1.47.0"
+
+func runVersionCmd(cmd *cobra.Command, args []string) error {
+	if printJSONResult(cmd, map[string]string{"version": version}, nil) {
+		return nil
+	}
+	fmt.Fprintln(resultOutput, version)
+	return nil
 }
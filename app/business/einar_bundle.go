@@ -0,0 +1,104 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// EinarBundleExport packs every cached tag of the template published at templateURL into a
+// .tar.gz bundle, one folder per tag next to the bundle manifest.
+var EinarBundleExport in.EinarBundleExport = func(ctx context.Context, templateURL, bundlePath string) (domain.ArchiveManifest, error) {
+	manifest := domain.ArchiveManifest{URL: templateURL}
+
+	targetPath, err := utils.GetTemplateFolderPath(templateURL)
+	if err != nil {
+		return manifest, err
+	}
+	dirs, err := utils.ListFirstLevelDirs(targetPath)
+	var tags []string
+	for _, dir := range dirs {
+		if utils.IsCacheEntryComplete(filepath.Join(targetPath, dir)) {
+			tags = append(tags, dir)
+		}
+	}
+	if err != nil || len(tags) == 0 {
+		return manifest, fmt.Errorf("no cached tags found for %s in %s", templateURL, targetPath)
+	}
+	manifest.Tags = tags
+
+	tmpDir, err := os.MkdirTemp("", "einar-bundle-")
+	if err != nil {
+		return manifest, fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, tag := range tags {
+		if err := utils.CopyDirectory(filepath.Join(targetPath, tag), filepath.Join(tmpDir, tag), nil, nil); err != nil {
+			return manifest, fmt.Errorf("error copying tag %s: %w", tag, err)
+		}
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return manifest, err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, domain.ArchiveManifestFile), manifestBytes, 0644); err != nil {
+		return manifest, err
+	}
+
+	if err := utils.CreateTarGz(tmpDir, bundlePath); err != nil {
+		return manifest, fmt.Errorf("error creating bundle %s: %w", bundlePath, err)
+	}
+	return manifest, nil
+}
+
+// EinarBundleImport unpacks a bundle created by EinarBundleExport into the template cache,
+// so the template can be used without reaching its repository.
+var EinarBundleImport in.EinarBundleImport = func(ctx context.Context, bundlePath string) (domain.ArchiveManifest, error) {
+	tmpDir, err := os.MkdirTemp("", "einar-bundle-")
+	if err != nil {
+		return domain.ArchiveManifest{}, fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := utils.ExtractArchive(bundlePath, tmpDir); err != nil {
+		return domain.ArchiveManifest{}, err
+	}
+	manifest, err := utils.ReadArchiveManifest(tmpDir)
+	if err != nil {
+		return manifest, err
+	}
+	if manifest.URL == "" || len(manifest.Tags) == 0 {
+		return manifest, fmt.Errorf("%s is not a template bundle", bundlePath)
+	}
+
+	targetPath, err := utils.GetTemplateFolderPath(manifest.URL)
+	if err != nil {
+		return manifest, err
+	}
+	// check every tag before touching the cache, a hostile bundle must not import any of them
+	tagFolderPaths := make([]string, len(manifest.Tags))
+	for i, tag := range manifest.Tags {
+		if tagFolderPaths[i], err = utils.CacheTagPath(targetPath, tag); err != nil {
+			return manifest, err
+		}
+	}
+	for i, tag := range manifest.Tags {
+		tagFolderPath := tagFolderPaths[i]
+		err := utils.InstallCacheEntry(tagFolderPath, true, func(dir string) error {
+			return utils.CopyDirectory(filepath.Join(tmpDir, tag), dir, nil, nil)
+		})
+		if err != nil {
+			return manifest, fmt.Errorf("error importing tag %s: %w", tag, err)
+		}
+		utils.Info("tag imported", "tag", tag, "template", manifest.URL, "path", tagFolderPath)
+	}
+	return manifest, nil
+}
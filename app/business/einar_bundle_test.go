@@ -0,0 +1,65 @@
+package business
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func TestEinarBundleImportRejectsHostileTags(t *testing.T) {
+	templateURL := "https://github.com/acme/hostile-bundle"
+	targetPath, err := utils.GetTemplateFolderPath(templateURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the folder a "../../victim" tag points at, next to the cache of the template
+	victim := filepath.Join(targetPath, "..", "..", "victim")
+	if err := os.MkdirAll(victim, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(victim)
+		os.RemoveAll(filepath.Dir(targetPath))
+	})
+	if err := os.WriteFile(filepath.Join(victim, "keep.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tag := range []string{"../../victim", "v1.0.0/../../../victim", "/tmp/victim", ""} {
+		t.Run(tag, func(t *testing.T) {
+			bundleDir := t.TempDir()
+			manifest := `{"url": "` + templateURL + `", "tags": ["v1.0.0", "` + tag + `"]}`
+			files := map[string]string{
+				".einar.archive.json":         manifest,
+				"v1.0.0/.einar.template.json": "{}",
+				"victim/.einar.template.json": "{}",
+			}
+			for path, content := range files {
+				path = filepath.Join(bundleDir, path)
+				if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+			if err := utils.CreateTarGz(bundleDir, bundlePath); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := EinarBundleImport(context.Background(), bundlePath); err == nil {
+				t.Fatalf("EinarBundleImport() error = nil, want an invalid tag error")
+			}
+			if content, err := os.ReadFile(filepath.Join(victim, "keep.txt")); err != nil || string(content) != "keep" {
+				t.Errorf("victim folder was modified: %q, %v", content, err)
+			}
+			if _, err := os.Stat(filepath.Join(targetPath, "v1.0.0")); !os.IsNotExist(err) {
+				t.Errorf("valid tag of a hostile bundle was imported")
+			}
+		})
+	}
+}
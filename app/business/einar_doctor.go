@@ -0,0 +1,160 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+	"golang.org/x/mod/modfile"
+)
+
+var EinarDoctor in.EinarDoctor = func(ctx context.Context, fix bool) (domain.DoctorReport, error) {
+	var report domain.DoctorReport
+
+	cli, err := utils.ReadEinarCli()
+	if err != nil {
+		return report, fmt.Errorf("failed to read .einar.cli.json: %w", err)
+	}
+
+	goMod, err := utils.ReadGoMod(".")
+	if err != nil {
+		return report, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	modulePath := goMod.Module.Mod.Path
+
+	var layers []domain.TemplateLayer
+	for _, template := range cli.TemplateLayers() {
+		templateFolderPath, err := utils.TemplateFolderPath(template)
+		if err != nil {
+			return report, err
+		}
+
+		einarTemplate, err := utils.ReadEinarTemplateFromBinaryPath(templateFolderPath)
+		if err != nil || !utils.IsTemplateCached(template) {
+			fixed := false
+			if fix {
+				if _, cloneErr := utils.FetchTemplate(template, "no-auth"); cloneErr == nil {
+					einarTemplate, err = utils.ReadEinarTemplateFromBinaryPath(templateFolderPath)
+					fixed = err == nil
+				}
+			}
+			report.Add(domain.CheckMissingTemplateCache,
+				fmt.Sprintf("template cache for %s missing or incomplete in %s", template, templateFolderPath),
+				fixed)
+		}
+		layers = append(layers, domain.TemplateLayer{Template: template, Path: templateFolderPath, EinarTemplate: einarTemplate})
+	}
+
+	if report.IsHealthy() {
+		resolved, err := domain.ResolveTemplateLayers(layers)
+		if err != nil {
+			return report, err
+		}
+		checkComponentFiles(&report, cli, resolved)
+	}
+
+	if err := checkBlankImports(&report, modulePath, fix); err != nil {
+		return report, err
+	}
+
+	if cli.Project != modulePath {
+		message := fmt.Sprintf("project %q in .einar.cli.json does not match go.mod module %q", cli.Project, modulePath)
+		fixed := false
+		if fix {
+			fixedCli := cli
+			fixedCli.Project = modulePath
+			fixed = utils.CreateEinarCLIJSON(fixedCli) == nil
+		}
+		report.Add(domain.CheckProjectMismatch, message, fixed)
+	}
+
+	for _, installation := range cli.Installations {
+		for _, library := range installation.Libraries {
+			if isRequired(library, goMod.Require) {
+				continue
+			}
+			fixed := false
+			if fix {
+				_, err := runGo(ctx, "", "get", library)
+				fixed = err == nil
+			}
+			report.Add(domain.CheckMissingLibrary,
+				fmt.Sprintf("library %s of installation %s is not required in go.mod", library, installation.Name),
+				fixed)
+		}
+	}
+
+	return report, nil
+}
+
+func checkComponentFiles(report *domain.DoctorReport, cli domain.EinarCli, resolved domain.ResolvedTemplate) {
+	for _, component := range cli.Components {
+		resolvedComponent, ok := resolved.Component(component.Kind)
+		if !ok {
+			continue
+		}
+		command := GetInstallCommandWithHighestMatches(cli, resolvedComponent.Commands)[0]
+		for _, file := range command.ComponentFiles {
+			destinationPath := componentDestinationPath(file, component.Name)
+			if _, err := utils.FS.Stat(destinationPath); os.IsNotExist(err) {
+				report.Add(domain.CheckMissingComponentFile,
+					fmt.Sprintf("%s %s is recorded but %s does not exist", component.Kind, component.Name, destinationPath),
+					false)
+			}
+		}
+	}
+}
+
+func checkBlankImports(report *domain.DoctorReport, modulePath string, fix bool) error {
+	setupFilePath := filepath.Join("main.go")
+	imports, err := utils.ListBlankImports(setupFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read imports of main.go: %w", err)
+	}
+
+	for _, importPath := range imports {
+		if !strings.HasPrefix(importPath, modulePath+"/") {
+			continue
+		}
+		packageDir := filepath.FromSlash(strings.TrimPrefix(importPath, modulePath+"/"))
+		if hasGoFiles(packageDir) {
+			continue
+		}
+		fixed := false
+		if fix {
+			fixed = utils.RemoveImportStatement(setupFilePath, importPath) == nil
+		}
+		report.Add(domain.CheckStaleImport,
+			fmt.Sprintf("main.go imports %s but %s has no Go files", importPath, packageDir),
+			fixed)
+	}
+	return nil
+}
+
+func hasGoFiles(dir string) bool {
+	entries, err := utils.FS.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".go" {
+			return true
+		}
+	}
+	return false
+}
+
+// isRequired reports whether library is provided by one of the modules required in go.mod.
+func isRequired(library string, requires []*modfile.Require) bool {
+	for _, require := range requires {
+		if library == require.Mod.Path || strings.HasPrefix(library, require.Mod.Path+"/") {
+			return true
+		}
+	}
+	return false
+}
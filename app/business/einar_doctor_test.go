@@ -0,0 +1,136 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+const doctorTemplate = `{
+	"installation_commands": [{"name": "echo-server"}],
+	"component_commands": [
+		{"kind": "get-controller", "depends_on": ["echo-server"], "files": [{
+			"source_file": "controller.go",
+			"destination_dir": "app/adapter/in/controller"
+		}]}
+	]
+}`
+
+// doctorProject returns the files of a healthy project, with overrides applied.
+func doctorProject(overrides map[string]string) map[string]string {
+	files := map[string]string{
+		"/templates/api/.einar.template.json": doctorTemplate,
+		"/templates/api/controller.go":        "package controller\n",
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""},
+			"installations": [{"name": "echo-server", "unique": "", "libraries": ["github.com/labstack/echo/v4"]}],
+			"components": [{"kind": "get-controller", "name": "list-orders"}]}`,
+		"go.mod":  "module shop\n\nrequire github.com/labstack/echo/v4 v4.11.3\n",
+		"main.go": "package main\n\nimport _ \"shop/app/adapter/in/controller\"\n\nfunc main() {}\n",
+		"app/adapter/in/controller/list_orders.go": "package controller\n",
+	}
+	for path, content := range overrides {
+		files[path] = content
+	}
+	return files
+}
+
+func TestEinarDoctor(t *testing.T) {
+	tests := []struct {
+		name        string
+		overrides   map[string]string
+		fix         bool
+		wantIssues  []domain.DoctorIssue
+		wantFile    string
+		wantText    string
+		wantProject string
+	}{
+		{
+			name: "healthy",
+		},
+		{
+			name: "missing component file",
+			overrides: map[string]string{
+				"main.go": "package main\n\nfunc main() {}\n",
+				".einar.cli.json": `{"schema_version": 1, "project": "shop",
+					"template": {"url": "file:///templates/api", "tag": ""},
+					"installations": [{"name": "echo-server", "unique": "", "libraries": null}],
+					"components": [{"kind": "get-controller", "name": "list-users"}]}`,
+			},
+			wantIssues: []domain.DoctorIssue{{Check: domain.CheckMissingComponentFile}},
+		},
+		{
+			name:       "stale import",
+			overrides:  map[string]string{"main.go": "package main\n\nimport (\n\t_ \"shop/app/adapter/in/controller\"\n\t_ \"shop/app/adapter/in/gone\"\n)\n\nfunc main() {}\n"},
+			wantIssues: []domain.DoctorIssue{{Check: domain.CheckStaleImport}},
+		},
+		{
+			name:       "stale import fixed",
+			overrides:  map[string]string{"main.go": "package main\n\nimport (\n\t_ \"shop/app/adapter/in/controller\"\n\t_ \"shop/app/adapter/in/gone\"\n)\n\nfunc main() {}\n"},
+			fix:        true,
+			wantIssues: []domain.DoctorIssue{{Check: domain.CheckStaleImport, Fixed: true}},
+			wantFile:   "main.go",
+			wantText:   "package main\n\nimport (\n\t_ \"shop/app/adapter/in/controller\"\n)\n\nfunc main() {}\n",
+		},
+		{
+			name:       "project mismatch",
+			overrides:  map[string]string{"go.mod": "module store\n\nrequire github.com/labstack/echo/v4 v4.11.3\n", "main.go": "package main\n\nfunc main() {}\n"},
+			wantIssues: []domain.DoctorIssue{{Check: domain.CheckProjectMismatch}},
+		},
+		{
+			name:        "project mismatch fixed",
+			overrides:   map[string]string{"go.mod": "module store\n\nrequire github.com/labstack/echo/v4 v4.11.3\n", "main.go": "package main\n\nfunc main() {}\n"},
+			fix:         true,
+			wantIssues:  []domain.DoctorIssue{{Check: domain.CheckProjectMismatch, Fixed: true}},
+			wantProject: "store",
+		},
+		{
+			name:       "missing library",
+			overrides:  map[string]string{"go.mod": "module shop\n"},
+			wantIssues: []domain.DoctorIssue{{Check: domain.CheckMissingLibrary}},
+		},
+		{
+			name: "missing template cache",
+			overrides: map[string]string{".einar.cli.json": `{"schema_version": 1, "project": "shop",
+				"template": {"url": "https://github.com/acme/missing", "tag": "v1.0.0"},
+				"installations": [{"name": "echo-server", "unique": "", "libraries": null}],
+				"components": [{"kind": "get-controller", "name": "list-orders"}]}`},
+			wantIssues: []domain.DoctorIssue{{Check: domain.CheckMissingTemplateCache}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useMemFileSystem(t, doctorProject(tt.overrides))
+
+			report, err := EinarDoctor(context.Background(), tt.fix)
+			if err != nil {
+				t.Fatalf("EinarDoctor() error = %v", err)
+			}
+			if len(report.Issues) != len(tt.wantIssues) {
+				t.Fatalf("EinarDoctor() issues = %v, want %v", report.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := report.Issues[i]; got.Check != want.Check || got.Fixed != want.Fixed {
+					t.Errorf("EinarDoctor() issue %d = %v, want check %s fixed %v", i, got, want.Check, want.Fixed)
+				}
+			}
+			if report.IsHealthy() != (len(tt.wantIssues) == 0 || tt.fix) {
+				t.Errorf("EinarDoctor() healthy = %v", report.IsHealthy())
+			}
+			if tt.wantFile != "" {
+				content, err := utils.FS.ReadFile(tt.wantFile)
+				if err != nil || string(content) != tt.wantText {
+					t.Errorf("%s = %q, %v, want %q", tt.wantFile, content, err, tt.wantText)
+				}
+			}
+			if tt.wantProject != "" {
+				config, err := utils.ReadEinarCli()
+				if err != nil || config.Project != tt.wantProject {
+					t.Errorf(".einar.cli.json project = %q, %v, want %s", config.Project, err, tt.wantProject)
+				}
+			}
+		})
+	}
+}
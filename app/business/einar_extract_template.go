@@ -0,0 +1,373 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// extractedModule is the module path of extracted templates. Generated projects replace it
+// with their own module path.
+const extractedModule = "archetype"
+
+// componentSentinel is the component name of extracted component files. Its case variants
+// are valid identifiers and paths, so the extracted template still compiles.
+const componentSentinel = "einar-component"
+
+// extractedCases are the name variants reverse-applied into replace_holders, by priority
+// when two variants of a component name are the same string.
+var extractedCases = []string{"PascalCase", "camelCase", "snake_case", "kebab", "SCREAMING_SNAKE_CASE", "dot.case", "Title Case"}
+
+var EinarExtractTemplate in.EinarExtractTemplate = func(ctx context.Context, outputDir string) (domain.EinarTemplate, error) {
+	var template domain.EinarTemplate
+
+	if entries, err := utils.FS.ReadDir(outputDir); err == nil && len(entries) > 0 {
+		return template, fmt.Errorf("output directory %s is not empty", outputDir)
+	}
+
+	cli, err := utils.ReadEinarCli()
+	if err != nil {
+		return template, fmt.Errorf("failed to read .einar.cli.json: %w", err)
+	}
+	goMod, err := utils.ReadGoMod(".")
+	if err != nil {
+		return template, fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	modulePath := goMod.Module.Mod.Path
+
+	resolved, err := loadTemplateLayers(cli)
+	if err != nil {
+		return template, err
+	}
+
+	// Collect the files generated for every recorded component and pick one component
+	// per kind, preferring names without nested folders, as the exemplar of the kind.
+	generatedFiles := make(map[string]bool)
+	exemplars := make(map[string]domain.Component)
+	commands := make(map[string]domain.ComponentCommands)
+	var kinds []string
+	for _, component := range cli.Components {
+		resolvedComponent, ok := resolved.Component(component.Kind)
+		if !ok {
+			utils.Warn("component kind not found in the project templates, skipping it", "kind", component.Kind, "component", component.Name)
+			continue
+		}
+		command := GetInstallCommandWithHighestMatches(cli, resolvedComponent.Commands)[0]
+		for _, file := range command.ComponentFiles {
+			generatedFiles[componentDestinationPath(file, component.Name)] = true
+			if file.Port.SourceFile != "" {
+				generatedFiles[filepath.Clean(componentPortPath(file, component.Name))] = true
+			}
+		}
+		exemplar, ok := exemplars[component.Kind]
+		if !ok {
+			kinds = append(kinds, component.Kind)
+		}
+		if !ok || (strings.Contains(exemplar.Name, "/") && !strings.Contains(component.Name, "/")) {
+			exemplars[component.Kind] = component
+			commands[component.Kind] = command
+		}
+	}
+
+	outputAbs, err := filepath.Abs(outputDir)
+	if err != nil {
+		return template, err
+	}
+	moduleReplacements := [][2]string{
+		{`"` + modulePath + `/`, `"` + extractedModule + `/`},
+		{`"` + modulePath + `"`, `"` + extractedModule + `"`},
+	}
+
+	template.BaseTemplate.Description = "template extracted from " + modulePath
+	if err := extractBaseTemplate(".", outputDir, outputAbs, generatedFiles, moduleReplacements, &template.BaseTemplate); err != nil {
+		return template, err
+	}
+
+	for _, kind := range kinds {
+		command, err := extractComponentCommand(commands[kind], exemplars[kind].Name, outputDir, moduleReplacements)
+		if err != nil {
+			return template, err
+		}
+		template.ComponentCommands = append(template.ComponentCommands, command)
+	}
+
+	if err := removeStaleImports(outputDir); err != nil {
+		return template, err
+	}
+
+	if err := goMod.AddModuleStmt(extractedModule); err != nil {
+		return template, err
+	}
+	goModBytes, err := goMod.Format()
+	if err != nil {
+		return template, err
+	}
+	if err := utils.FS.WriteFile(filepath.Join(outputDir, "go.mod"), goModBytes, 0644); err != nil {
+		return template, err
+	}
+	if goSum, err := utils.FS.ReadFile("go.sum"); err == nil {
+		if err := utils.FS.WriteFile(filepath.Join(outputDir, "go.sum"), goSum, 0644); err != nil {
+			return template, err
+		}
+	}
+
+	templateBytes, err := json.MarshalIndent(template, "", "    ")
+	if err != nil {
+		return template, fmt.Errorf("failed to marshal .einar.template.json: %w", err)
+	}
+	if err := utils.FS.WriteFile(filepath.Join(outputDir, ".einar.template.json"), templateBytes, 0644); err != nil {
+		return template, fmt.Errorf("failed to write .einar.template.json: %w", err)
+	}
+	utils.Info("template extracted", "path", outputDir)
+	return template, nil
+}
+
+// extractBaseTemplate copies dir into outputDir. Directories without generated component
+// files become base folders, the remaining files are listed one by one.
+func extractBaseTemplate(
+	dir string,
+	outputDir string,
+	outputAbs string,
+	generatedFiles map[string]bool,
+	replacements [][2]string,
+	baseTemplate *domain.BaseTemplate) error {
+	entries, err := utils.FS.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if abs, err := filepath.Abs(path); err == nil && abs == outputAbs {
+			continue
+		}
+		if dir == "." && (entry.Name() == ".git" || entry.Name() == ".einar" ||
+			entry.Name() == ".einar.cli.json" || entry.Name() == "go.mod" || entry.Name() == "go.sum") {
+			continue
+		}
+
+		if !entry.IsDir() {
+			if generatedFiles[path] {
+				continue
+			}
+			if _, err := extractFile(path, filepath.Join(outputDir, path), replacements, nil); err != nil {
+				return err
+			}
+			baseTemplate.Files = append(baseTemplate.Files, domain.BaseFile{
+				SourceFile:      filepath.ToSlash(path),
+				DestinationFile: filepath.ToSlash(path),
+			})
+			continue
+		}
+
+		if containsGeneratedFile(path, generatedFiles) {
+			if err := extractBaseTemplate(path, outputDir, outputAbs, generatedFiles, replacements, baseTemplate); err != nil {
+				return err
+			}
+			continue
+		}
+		files, err := utils.ListFiles(path)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			file = filepath.Join(path, filepath.FromSlash(file))
+			if _, err := extractFile(file, filepath.Join(outputDir, file), replacements, nil); err != nil {
+				return err
+			}
+		}
+		baseTemplate.Folders = append(baseTemplate.Folders, domain.BaseFolder{
+			SourceDir:      filepath.ToSlash(path),
+			DestinationDir: filepath.ToSlash(path),
+		})
+	}
+	return nil
+}
+
+func containsGeneratedFile(dir string, generatedFiles map[string]bool) bool {
+	for file := range generatedFiles {
+		if strings.HasPrefix(file, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractComponentCommand copies the files generated for componentName into outputDir under
+// the sentinel name and turns every case variant of componentName into a replace holder.
+// The installations the kind depends on are part of the extracted base template, so the
+// extracted kind depends on nothing, declared like templates do with an empty dependency.
+func extractComponentCommand(
+	command domain.ComponentCommands,
+	componentName string,
+	outputDir string,
+	moduleReplacements [][2]string) (domain.ComponentCommands, error) {
+	name := componentName[strings.LastIndex(componentName, "/")+1:]
+
+	// Variants are replaced longest first so that no variant replaces part of a longer one.
+	variants := make(map[string]string)
+	var variantOrder []string
+	for _, caseType := range extractedCases {
+		variant := utils.ConvertStringCase(name, caseType)
+		if _, ok := variants[variant]; ok || variant == "" {
+			continue
+		}
+		variants[variant] = caseType
+		variantOrder = append(variantOrder, variant)
+	}
+	sort.SliceStable(variantOrder, func(i, j int) bool { return len(variantOrder[i]) > len(variantOrder[j]) })
+
+	var nameReplacements [][2]string
+	for _, variant := range variantOrder {
+		nameReplacements = append(nameReplacements, [2]string{variant, utils.ConvertStringCase(componentSentinel, variants[variant])})
+	}
+
+	extracted := domain.ComponentCommands{Kind: command.Kind, Name: command.Name, DependsOn: []string{""}}
+	for _, file := range command.ComponentFiles {
+		used := make(map[string]bool)
+		from := componentDestinationPath(file, componentName)
+		to := componentDestinationPath(file, componentSentinel)
+		found, err := extractFile(from, filepath.Join(outputDir, to), moduleReplacements, nameReplacements)
+		if err != nil {
+			return extracted, err
+		}
+		for _, v := range found {
+			used[v] = true
+		}
+
+		extractedFile := file
+		extractedFile.SourceFile = filepath.ToSlash(to)
+		extractedFile.LiteralReplacements = nil
+		extractedFile.ReplaceHolders = nil
+		if file.Port.SourceFile != "" {
+			portFrom := componentPortPath(file, componentName)
+			portTo := componentPortPath(file, componentSentinel)
+			found, err := extractFile(portFrom, filepath.Join(outputDir, portTo), moduleReplacements, nameReplacements)
+			if err != nil {
+				return extracted, err
+			}
+			for _, v := range found {
+				used[v] = true
+			}
+			extractedFile.Port.SourceFile = filepath.ToSlash(filepath.Clean(portTo))
+		}
+
+		for _, variant := range variantOrder {
+			if !used[variant] {
+				continue
+			}
+			caseType := variants[variant]
+			extractedFile.ReplaceHolders = append(extractedFile.ReplaceHolders, domain.ReplaceHolder{
+				Kind: caseType,
+				Name: utils.ConvertStringCase(componentSentinel, caseType),
+			})
+		}
+		extracted.ComponentFiles = append(extracted.ComponentFiles, extractedFile)
+	}
+	return extracted, nil
+}
+
+// extractFile copies src to dst applying replacements to text files and returns the
+// replaced strings that were found in src. Identifiers are only replaced where they are
+// whole words, so a component named user leaves username and UserService alone.
+func extractFile(src, dst string, replacements, identifiers [][2]string) ([]string, error) {
+	content, err := utils.FS.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", src, err)
+	}
+
+	var found []string
+	if !bytes.Contains(content, []byte{0}) {
+		updated := string(content)
+		for _, replacement := range replacements {
+			if strings.Contains(updated, replacement[0]) {
+				found = append(found, replacement[0])
+				updated = strings.ReplaceAll(updated, replacement[0], replacement[1])
+			}
+		}
+		for _, identifier := range identifiers {
+			var replaced bool
+			if updated, replaced = replaceIdentifier(updated, identifier[0], identifier[1]); replaced {
+				found = append(found, identifier[0])
+			}
+		}
+		content = []byte(updated)
+	}
+
+	if err := utils.FS.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating directory %s: %v", filepath.Dir(dst), err)
+	}
+	if err := utils.FS.WriteFile(dst, content, 0644); err != nil {
+		return nil, fmt.Errorf("error writing %s: %w", dst, err)
+	}
+	return found, nil
+}
+
+// replaceIdentifier replaces the occurrences of old in s that are not part of a longer
+// identifier and reports whether there were any.
+func replaceIdentifier(s, old, new string) (string, bool) {
+	var b strings.Builder
+	replaced := false
+	last := 0
+	for start := 0; ; {
+		i := strings.Index(s[start:], old)
+		if i < 0 {
+			break
+		}
+		i += start
+		end := i + len(old)
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (i == 0 || !isIdentifierRune(before)) && (end == len(s) || !isIdentifierRune(after)) {
+			b.WriteString(s[last:i])
+			b.WriteString(new)
+			last = end
+			replaced = true
+			start = end
+			continue
+		}
+		start = i + 1
+	}
+	b.WriteString(s[last:])
+	return b.String(), replaced
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// removeStaleImports drops the blank imports of the extracted main.go whose packages were
+// generated for components and are not part of the template.
+func removeStaleImports(outputDir string) error {
+	mainPath := filepath.Join(outputDir, "main.go")
+	imports, err := utils.ListBlankImports(mainPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, importPath := range imports {
+		if !strings.HasPrefix(importPath, extractedModule+"/") {
+			continue
+		}
+		packageDir := filepath.Join(outputDir, filepath.FromSlash(strings.TrimPrefix(importPath, extractedModule+"/")))
+		if hasGoFiles(packageDir) {
+			continue
+		}
+		if err := utils.RemoveImportStatement(mainPath, importPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package business
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func TestReplaceIdentifier(t *testing.T) {
+	tests := []struct {
+		s, old, new  string
+		want         string
+		wantReplaced bool
+	}{
+		{"type User struct{}", "User", "EinarComponent", "type EinarComponent struct{}", true},
+		{"var username string", "user", "einarComponent", "var username string", false},
+		{"type UserService struct{}", "User", "EinarComponent", "type UserService struct{}", false},
+		{"func GetUser(user User) {}", "User", "EinarComponent", "func GetUser(user EinarComponent) {}", true},
+		{"e.GET(\"/user\", user)", "user", "einarComponent", "e.GET(\"/einarComponent\", einarComponent)", true},
+		{"UserUser User", "User", "X", "UserUser X", true},
+		{"list_orders_test list_orders", "list_orders", "einar_component", "list_orders_test einar_component", true},
+		{"list-orders.go", "list-orders", "einar-component", "einar-component.go", true},
+		{"", "user", "x", "", false},
+	}
+	for _, tt := range tests {
+		got, replaced := replaceIdentifier(tt.s, tt.old, tt.new)
+		if got != tt.want || replaced != tt.wantReplaced {
+			t.Errorf("replaceIdentifier(%q, %q) = %q, %v, want %q, %v", tt.s, tt.old, got, replaced, tt.want, tt.wantReplaced)
+		}
+	}
+}
+
+func TestEinarExtractTemplateGeneratesEveryKind(t *testing.T) {
+	templateDir := t.TempDir()
+	templateFiles := map[string]string{
+		"go.mod": "module archetype\n",
+		".einar.template.json": `{
+			"installation_commands": [{"name": "echo-server"}],
+			"component_commands": [
+				{"kind": "get-controller", "depends_on": ["echo-server"], "files": [{
+					"source_file": "controller.go",
+					"destination_dir": "app/adapter/in/controller",
+					"replace_holders": [{"kind": "PascalCase", "name": "Template"}]
+				}]},
+				{"kind": "repository", "depends_on": [""], "files": [{
+					"source_file": "repository.go",
+					"destination_dir": "app/adapter/out/repository",
+					"replace_holders": [{"kind": "PascalCase", "name": "Template"}]
+				}]}
+			]
+		}`,
+		"controller.go": "package controller\n\nfunc Template() {}\n",
+		"repository.go": "package repository\n\nfunc Template() {}\n",
+	}
+	for path, content := range templateFiles {
+		if err := os.WriteFile(filepath.Join(templateDir, path), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	useProjectDir(t, map[string]string{
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "` + utils.LocalTemplateScheme + filepath.ToSlash(templateDir) + `", "tag": ""},
+			"installations": [{"name": "echo-server", "unique": "", "libraries": null}],
+			"components": [{"kind": "get-controller", "name": "list-orders"}, {"kind": "repository", "name": "orders"}]}`,
+		"go.mod":  "module shop\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+		"app/adapter/in/controller/list_orders.go": "package controller\n\nfunc ListOrders() {}\n",
+		"app/adapter/out/repository/orders.go":     "package repository\n\nfunc Orders() {}\n",
+		"app/shared/server/server.go":              "package server\n",
+	})
+	ctx := context.Background()
+
+	extracted, err := EinarExtractTemplate(ctx, "out")
+	if err != nil {
+		t.Fatalf("EinarExtractTemplate() error = %v", err)
+	}
+	outputDir, _ := filepath.Abs("out")
+
+	// a project created from the extracted template records no installations
+	projectDir := t.TempDir()
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".einar.cli.json": `{"schema_version": 1, "project": "store",
+			"template": {"url": "` + utils.LocalTemplateScheme + filepath.ToSlash(outputDir) + `", "tag": ""}}`,
+		"go.mod":  "module store\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(extracted.ComponentCommands) != 2 {
+		t.Fatalf("EinarExtractTemplate() kinds = %v, want get-controller and repository", extracted.ComponentCommands)
+	}
+	for _, command := range extracted.ComponentCommands {
+		changes, err := EinarGenerate(ctx, "store", command.Kind, "find-users")
+		if err != nil {
+			t.Errorf("EinarGenerate(%s) from the extracted template error = %v", command.Kind, err)
+			continue
+		}
+		content, err := os.ReadFile(changes.Files[0])
+		if err != nil || !strings.Contains(string(content), "func FindUsers() {}") {
+			t.Errorf("%s = %q, %v, want the component renamed", changes.Files[0], content, err)
+		}
+	}
+}
+
+func TestEinarExtractTemplateInMemory(t *testing.T) {
+	dir := useProjectDir(t, nil)
+	useMemFileSystem(t, map[string]string{
+		"/templates/api/go.mod": "module archetype\n",
+		"/templates/api/.einar.template.json": `{
+			"installation_commands": [{"name": "echo-server"}],
+			"component_commands": [
+				{"kind": "repository", "depends_on": [""], "files": [{
+					"source_file": "repository.go",
+					"destination_dir": "app/adapter/out/repository",
+					"replace_holders": [{"kind": "PascalCase", "name": "Template"}]
+				}]}
+			]
+		}`,
+		"/templates/api/repository.go": "package repository\n\nfunc Template() {}\n",
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""},
+			"components": [{"kind": "repository", "name": "orders"}]}`,
+		"go.mod":                               "module shop\n",
+		"go.sum":                               "",
+		"main.go":                              "package main\n\nfunc main() {}\n",
+		"app/adapter/out/repository/orders.go": "package repository\n\nfunc Orders() {}\n",
+		"app/shared/server/server.go":          "package server\n",
+	})
+
+	if _, err := EinarExtractTemplate(context.Background(), "out"); err != nil {
+		t.Fatalf("EinarExtractTemplate() error = %v", err)
+	}
+	for _, file := range []string{".einar.template.json", "go.mod", "go.sum", "main.go", "app/shared/server/server.go", "app/adapter/out/repository/einar_component.go"} {
+		if _, err := utils.FS.ReadFile(filepath.Join("out", file)); err != nil {
+			t.Errorf("ReadFile(%s) error = %v, want it extracted to the filesystem", file, err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+		t.Errorf("project folder on disk = %v, want nothing written to the disk", entries)
+	}
+}
@@ -2,10 +2,7 @@ package business
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -19,107 +16,59 @@ var EinarGenerate in.EinarGenerate = func(
 	ctx context.Context,
 	project string,
 	componentKind string,
-	componentName string) error {
+	componentName string) (domain.ChangeSet, error) {
+	var changes domain.ChangeSet
 
-	// read einar.cli.json
-	cliPath := filepath.Join(".einar.cli.json")
-	cliBytes, err := ioutil.ReadFile(cliPath)
+	cli, err := utils.ReadEinarCli()
 	if err != nil {
-		return fmt.Errorf("failed to read .einar.cli.json: %v", err)
+		return changes, fmt.Errorf("failed to read .einar.cli.json: %w", err)
 	}
 
-	var cli domain.EinarCli
-	err = json.Unmarshal(cliBytes, &cli)
+	resolved, err := loadTemplateLayers(cli)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal .einar.cli.json: %v", err)
+		return changes, fmt.Errorf("%w for project %v", err, project)
 	}
 
-	tagFolder := ""
-	if cli.Template.Tag != "" {
-		tagFolder = "/" + cli.Template.Tag
+	component, ok := resolved.Component(componentKind)
+	if !ok {
+		return changes, domain.Errorf(domain.ErrorKindUnknown, "%s command not found in .einar.template.json", componentKind)
 	}
+	templateFolderPath := resolved.LayerPath(component.Layer)
 
-	templateFolderPath, err := utils.GetTemplateFolderPath(cli.Template.URL + tagFolder)
+	files, err := trackGeneratedFiles("component:"+componentKind+"/"+componentName, templateFolderPath)
 	if err != nil {
-		return err
+		return changes, err
 	}
 
-	jsonFilePath := filepath.Join(templateFolderPath, ".einar.template.json")
-	jsonContentBytes, err := ioutil.ReadFile(jsonFilePath)
-	if err != nil {
-		utils.GitCloneTemplateInBinaryPath(cli.Template.URL, "no-auth", cli.Template.Tag)
-		jsonFilePath := filepath.Join(templateFolderPath, ".einar.template.json")
-		jsonContentBytes, err = ioutil.ReadFile(jsonFilePath)
-	}
-
-	if err != nil {
-		return fmt.Errorf("error reading JSON file: %v for project %v", err, project)
-	}
-
-	var template domain.EinarTemplate
-	err = json.Unmarshal(jsonContentBytes, &template)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling JSON file: %v for project %v", err, project)
-	}
-
-	var installCommands []domain.ComponentCommands
-	for _, command := range template.ComponentCommands {
-		if command.Kind == componentKind {
-			installCommands = append(installCommands, command)
-		}
-	}
-
-	if len(installCommands) == 0 {
-		fmt.Printf("%s command not found in .einar.template.json", componentKind)
-		return fmt.Errorf("%s command not found in .einar.template.json", componentKind)
-	}
-
-	installCommands = GetInstallCommandWithHighestMatches(
+	installCommands := GetInstallCommandWithHighestMatches(
 		cli,
-		installCommands)
+		component.Commands)
 
 	for _, v := range cli.Components {
 		if v.Kind == componentKind && v.Name == componentName {
-			fmt.Printf("The component '%s' for '%s' already exists.\n", componentName, componentKind)
-			return errors.New("component already exists")
+			return changes, domain.Errorf(domain.ErrorAlreadyExists, "the component '%s' for '%s' already exists", componentName, componentKind)
 		}
 	}
 
-	var dependencyIsPresent bool
-	for _, dependency := range installCommands[0].DependsOn {
-		if dependency == "" {
-			dependencyIsPresent = true
-			break
-		}
-		for _, installation := range cli.Installations {
-			if dependency == installation.Name {
-				dependencyIsPresent = true
-				break
-			}
-			if dependency == installation.Unique {
-				dependencyIsPresent = true
-				break
-			}
-		}
-	}
-
-	if !dependencyIsPresent {
-		fmt.Println("Some dependencies are missing. Please install the following dependencies:")
+	if !cli.HasDependency(installCommands[0].DependsOn) {
 		for _, v := range installCommands[0].DependsOn {
-			fmt.Println("einar install " + v)
+			if !cli.HasDependency([]string{v}) {
+				utils.Warn("missing dependency, run einar install "+v, "kind", componentKind, "dependency", v)
+			}
 		}
-		return errors.New("dependencies are not present")
+		return changes, domain.Errorf(domain.ErrorDependencyMissing, "dependencies are not present")
 	}
 
 	setupFilePath := filepath.Join("main.go")
 
-	if err := addComponentInsideCli(componentKind, componentName); err != nil {
-		return fmt.Errorf("failed to update .einar.template.json: %v", err)
-	}
-
 	// Iterate over the Files slice
 	for _, file := range installCommands[0].ComponentFiles {
 
+		destinationPath := componentDestinationPath(file, componentName)
+		portDestinationPath := componentPortPath(file, componentName)
+		utils.Debug("component paths computed", "kind", componentKind, "source", file.SourceFile,
+			"destination", destinationPath, "port", portDestinationPath)
+
 		// Extract the final component name and construct the nested folder structure
 		componentParts := strings.Split(componentName, "/")
 		nestedFolders := strings.Join(componentParts[:len(componentParts)-1], "/")
@@ -140,20 +89,14 @@ var EinarGenerate in.EinarGenerate = func(
 			}
 			err := utils.AddImportStatement(setupFilePath, importPath)
 			if err != nil {
-				return fmt.Errorf("failed to add import statement to setup.go: %v", err)
+				return changes, fmt.Errorf("failed to add import statement to setup.go: %w", err)
 			}
+			changes.Imports = append(changes.Imports, importPath)
+			files.edit(setupFilePath)
 		}
 
 		// Construct the source and destination paths
-		sourcePath := filepath.Join(templateFolderPath, file.SourceFile)
-		var destinationPath string
-
-		if file.HasComponentDir {
-			component := utils.ConvertStringCase(componentName, "snake_case")
-			destinationPath = filepath.Join(baseFolder, nestedFolders, file.DestinationDir, component, component+file.AppendAtEnd+filepath.Ext(file.SourceFile))
-		} else {
-			destinationPath = filepath.Join(baseFolder, nestedFolders, file.DestinationDir, utils.ConvertStringCase(componentName, "snake_case")+file.AppendAtEnd+filepath.Ext(file.SourceFile))
-		}
+		sourcePath := resolved.SourcePath(component.Layer, file.SourceFile)
 
 		moduleName, err := utils.ReadTemplateModuleName(templateFolderPath)
 
@@ -179,72 +122,90 @@ var EinarGenerate in.EinarGenerate = func(
 		}
 
 		if file.Port.SourceFile != "" {
-			sourcePath := filepath.Join(templateFolderPath, file.Port.SourceFile)
-			destinationPath := baseFolder + "/" + nestedFolders + file.Port.DestinationDir + "/" + utils.ConvertStringCase(componentName, "snake_case") + filepath.Ext(file.Port.SourceFile)
-			err = utils.CopyFile(sourcePath, destinationPath, placeHolders, placeHoldersReplace)
+			sourcePath := resolved.SourcePath(component.Layer, file.Port.SourceFile)
+			err = utils.CopyFile(sourcePath, portDestinationPath, placeHolders, placeHoldersReplace)
+			files.write(portDestinationPath)
 		}
 
 		if err != nil {
-			return fmt.Errorf("error copying file from %s to %s: %v for project %v", sourcePath, destinationPath, err, project)
+			return changes, fmt.Errorf("error copying file from %s to %s: %w for project %v", sourcePath, destinationPath, err, project)
 		}
 
 		err = utils.CopyFile(sourcePath, destinationPath, placeHolders, placeHoldersReplace)
 		if err != nil {
-			return fmt.Errorf("error copying file from %s to %s: %v for project %v", sourcePath, destinationPath, err, project)
+			return changes, fmt.Errorf("error copying file from %s to %s: %w for project %v", sourcePath, destinationPath, err, project)
 		}
-		fmt.Printf("File copied successfully from %s to %s.\n", sourcePath, destinationPath)
+		files.write(destinationPath)
+		utils.Info("component file generated", "source", sourcePath, "destination", destinationPath)
 		componentName = nestedFolders + componentName
 	}
-	return nil
-}
 
-func addComponentInsideCli(componentKind string, componentName string) error {
-	// read einar.cli.json
-	cliPath := filepath.Join(".einar.cli.json")
-	cliBytes, err := ioutil.ReadFile(cliPath)
-	if err != nil {
-		return fmt.Errorf("failed to read .einar.cli.json: %v", err)
-	}
-
-	var cli domain.EinarCli
-	err = json.Unmarshal(cliBytes, &cli)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal .einar.cli.json: %v", err)
+	if len(installCommands[0].Injections) > 0 {
+		moduleName, err := utils.ReadTemplateModuleName(templateFolderPath)
+		if err != nil {
+			return changes, fmt.Errorf("error reading template module path: %w", err)
+		}
+		if err := applyInjections(
+			resolved,
+			component.Layer,
+			componentKind+"/"+componentName,
+			componentName[strings.LastIndex(componentName, "/")+1:],
+			installCommands[0].Injections,
+			[]string{`"` + moduleName},
+			[]string{`"` + project}); err != nil {
+			return changes, err
+		}
+		for _, injection := range installCommands[0].Injections {
+			files.edit(injection.DestinationFile)
+		}
 	}
 
-	tagFolder := ""
-	if cli.Template.Tag != "" {
-		tagFolder = "/" + cli.Template.Tag
-	}
-	templateFolderPath, err := utils.GetTemplateFolderPath(cli.Template.URL + tagFolder)
-	if err != nil {
-		return err
+	// the component is recorded once its files and injections are in place, so a failed
+	// generation can be run again
+	if err := addComponentInsideCli(componentKind, componentName); err != nil {
+		return changes, fmt.Errorf("failed to update .einar.template.json: %w", err)
 	}
 
-	// read einar.template.json
-	templatePath := filepath.Join(templateFolderPath, ".einar.template.json")
-	templateBytes, err := ioutil.ReadFile(templatePath)
-	if err != nil {
-		return fmt.Errorf("failed to read .einar.template.json: %v", err)
+	if err := files.save(); err != nil {
+		return changes, fmt.Errorf("failed to update %s: %w", domain.ManifestFile, err)
 	}
+	changes.Files = files.paths()
+	changes.Components = append(changes.Components, domain.Component{Kind: componentKind, Name: componentName})
+	changes.Warnings = resolved.Warnings
+	return changes, nil
+}
 
-	var template domain.EinarTemplate
-	err = json.Unmarshal(templateBytes, &template)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal .einar.template.json: %v", err)
+// componentDestinationPath returns the project path where a component file is generated.
+// Slash separated prefixes of componentName become nested folders below the base folder.
+func componentDestinationPath(file domain.ComponentFile, componentName string) string {
+	componentParts := strings.Split(componentName, "/")
+	nestedFolders := strings.Join(componentParts[:len(componentParts)-1], "/")
+	component := utils.ConvertStringCase(componentParts[len(componentParts)-1], "snake_case")
+	baseFolder := strings.Split(file.DestinationDir, "/")[0]
+	destinationDir := strings.TrimPrefix(file.DestinationDir, baseFolder+"/")
+	if file.HasComponentDir {
+		return filepath.Join(baseFolder, nestedFolders, destinationDir, component, component+file.AppendAtEnd+filepath.Ext(file.SourceFile))
 	}
+	return filepath.Join(baseFolder, nestedFolders, destinationDir, component+file.AppendAtEnd+filepath.Ext(file.SourceFile))
+}
 
-	// find the command
-	var command domain.ComponentCommands
-	for _, cmd := range template.ComponentCommands {
-		if cmd.Kind == componentKind {
-			command = cmd
-			break
-		}
+// componentPortPath returns the project path where the port of a component file is generated.
+func componentPortPath(file domain.ComponentFile, componentName string) string {
+	componentParts := strings.Split(componentName, "/")
+	nestedFolders := strings.Join(componentParts[:len(componentParts)-1], "/")
+	if nestedFolders != "" {
+		nestedFolders += "/"
 	}
+	component := utils.ConvertStringCase(componentParts[len(componentParts)-1], "snake_case")
+	baseFolder := strings.Split(file.DestinationDir, "/")[0]
+	portDir := strings.TrimPrefix(file.Port.DestinationDir, baseFolder+"/")
+	return baseFolder + "/" + nestedFolders + portDir + "/" + component + filepath.Ext(file.Port.SourceFile)
+}
 
-	if command.Kind == "" {
-		return fmt.Errorf("command %s not found in .einar.template.json", componentKind)
+func addComponentInsideCli(componentKind string, componentName string) error {
+	cli, err := utils.ReadEinarCli()
+	if err != nil {
+		return fmt.Errorf("failed to read .einar.cli.json: %w", err)
 	}
 
 	// add the command to the CLI
@@ -254,14 +215,8 @@ func addComponentInsideCli(componentKind string, componentName string) error {
 	})
 
 	// write back the updated einar.cli.json
-	cliBytes, err = json.MarshalIndent(cli, "", "    ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal .einar.cli.json: %v", err)
-	}
-
-	err = ioutil.WriteFile(cliPath, cliBytes, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write .einar.cli.json: %v", err)
+	if err := utils.CreateEinarCLIJSON(cli); err != nil {
+		return fmt.Errorf("failed to write .einar.cli.json: %w", err)
 	}
 
 	return nil
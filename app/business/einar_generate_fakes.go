@@ -0,0 +1,146 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// PortsDirs lists the folders scanned by EinarGenerateFakes. Each fake package is written
+// next to its ports, e.g. app/domain/ports/out/outfake.
+var PortsDirs = []string{"app/domain/ports/in", "app/domain/ports/out"}
+
+var EinarGenerateFakes in.EinarGenerateFakes = func(ctx context.Context, project string) error {
+	for _, portsDir := range PortsDirs {
+		if _, err := utils.FS.Stat(portsDir); os.IsNotExist(err) {
+			continue
+		}
+
+		ports, err := utils.LoadPorts(project, portsDir)
+		if err != nil {
+			return err
+		}
+
+		fakePackage := filepath.Base(portsDir) + "fake"
+		fakeDir := filepath.Join(portsDir, fakePackage)
+		for _, port := range ports {
+			content, err := renderFake(path.Join(project, filepath.ToSlash(fakeDir)), fakePackage, port)
+			if err != nil {
+				return fmt.Errorf("error generating fake for %s: %w", port.Name, err)
+			}
+			if err := utils.FS.MkdirAll(fakeDir, os.ModePerm); err != nil {
+				return fmt.Errorf("error creating directory %s: %w", fakeDir, err)
+			}
+			fileName := utils.ConvertStringCase(port.Name, "snake_case") + ".go"
+			destinationPath := filepath.Join(fakeDir, fileName)
+			if err := utils.FS.WriteFile(destinationPath, content, 0644); err != nil {
+				return fmt.Errorf("error writing %s: %w", destinationPath, err)
+			}
+			utils.Info("fake generated", "port", port.Name, "path", destinationPath)
+		}
+	}
+	return nil
+}
+
+func renderFake(pkgPath, pkgName string, port utils.Port) ([]byte, error) {
+	source := newPortSource(pkgPath)
+	source.addImport("sync", "sync")
+	source.addImport("testing", "testing")
+	portType := source.portType(port)
+	name := port.Name
+	// the closure returned by Fn refers to the receiver and to the call type
+	params, paramNames := source.params(port.Signature, "f", name+"Call")
+
+	var callFields, callValues, args []string
+	for i, paramName := range paramNames {
+		param := port.Signature.Params().At(i)
+		field := strings.ToUpper(paramName[:1]) + paramName[1:]
+		if param.Name() != "" && param.Name() != "_" {
+			field = strings.ToUpper(param.Name()[:1]) + param.Name()[1:]
+		}
+		callFields = append(callFields, field+" "+source.typeString(param.Type()))
+		callValues = append(callValues, field+": "+paramName)
+		if port.Signature.Variadic() && i == len(paramNames)-1 {
+			paramName += "..."
+		}
+		args = append(args, paramName)
+	}
+
+	var returnFields, returnValues []string
+	for i := 0; i < port.Signature.Results().Len(); i++ {
+		result := port.Signature.Results().At(i)
+		field := fmt.Sprintf("R%d", i)
+		if result.Name() != "" && result.Name() != "_" {
+			field = strings.ToUpper(result.Name()[:1]) + result.Name()[1:]
+		}
+		returnFields = append(returnFields, field+" "+source.typeString(result.Type()))
+		returnValues = append(returnValues, "f.Returns."+field)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "// %s is a configurable fake of %s that records every call.\n", name, portType)
+	fmt.Fprintf(&body, "// Func takes precedence over Returns when set.\n")
+	fmt.Fprintf(&body, "type %s struct {\n", name)
+	fmt.Fprintf(&body, "\tmu      sync.Mutex\n")
+	fmt.Fprintf(&body, "\tCalls   []%sCall\n", name)
+	fmt.Fprintf(&body, "\tReturns %sReturns\n", name)
+	fmt.Fprintf(&body, "\tFunc    %s\n", portType)
+	fmt.Fprintf(&body, "}\n\n")
+
+	fmt.Fprintf(&body, "// %sCall holds the arguments of a single call.\n", name)
+	fmt.Fprintf(&body, "type %sCall struct {\n", name)
+	for _, field := range callFields {
+		fmt.Fprintf(&body, "\t%s\n", field)
+	}
+	fmt.Fprintf(&body, "}\n\n")
+
+	fmt.Fprintf(&body, "// %sReturns holds the canned results.\n", name)
+	fmt.Fprintf(&body, "type %sReturns struct {\n", name)
+	for _, field := range returnFields {
+		fmt.Fprintf(&body, "\t%s\n", field)
+	}
+	fmt.Fprintf(&body, "}\n\n")
+
+	fmt.Fprintf(&body, "// Fn returns a %s backed by the fake.\n", portType)
+	fmt.Fprintf(&body, "func (f *%s) Fn() %s {\n", name, portType)
+	fmt.Fprintf(&body, "\treturn func(%s)%s {\n", params, source.results(port.Signature))
+	fmt.Fprintf(&body, "\t\tf.mu.Lock()\n")
+	fmt.Fprintf(&body, "\t\tf.Calls = append(f.Calls, %sCall{%s})\n", name, strings.Join(callValues, ", "))
+	fmt.Fprintf(&body, "\t\tf.mu.Unlock()\n")
+	fmt.Fprintf(&body, "\t\tif f.Func != nil {\n")
+	if port.Signature.Results().Len() > 0 {
+		fmt.Fprintf(&body, "\t\t\treturn f.Func(%s)\n", strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(&body, "\t\t\tf.Func(%s)\n\t\t\treturn\n", strings.Join(args, ", "))
+	}
+	fmt.Fprintf(&body, "\t\t}\n")
+	if len(returnValues) > 0 {
+		fmt.Fprintf(&body, "\t\treturn %s\n", strings.Join(returnValues, ", "))
+	}
+	fmt.Fprintf(&body, "\t}\n")
+	fmt.Fprintf(&body, "}\n\n")
+
+	fmt.Fprintf(&body, "// CallCount returns how many times the fake has been called.\n")
+	fmt.Fprintf(&body, "func (f *%s) CallCount() int {\n", name)
+	fmt.Fprintf(&body, "\tf.mu.Lock()\n\tdefer f.mu.Unlock()\n\treturn len(f.Calls)\n")
+	fmt.Fprintf(&body, "}\n\n")
+
+	fmt.Fprintf(&body, "// Swap replaces the package-level variable pointed by target with the fake\n")
+	fmt.Fprintf(&body, "// and restores the previous value when the test finishes.\n")
+	fmt.Fprintf(&body, "func (f *%s) Swap(t testing.TB, target *%s) {\n", name, portType)
+	fmt.Fprintf(&body, "\tprevious := *target\n")
+	fmt.Fprintf(&body, "\t*target = f.Fn()\n")
+	fmt.Fprintf(&body, "\tt.Cleanup(func() { *target = previous })\n")
+	fmt.Fprintf(&body, "}\n")
+
+	content := "// Code generated by einar generate-fakes. DO NOT EDIT.\n\n" +
+		"package " + pkgName + "\n\n" + source.importBlock() + "\n" + body.String()
+	return format.Source([]byte(content))
+}
@@ -0,0 +1,73 @@
+package business
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func TestEinarGenerateFakes(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is needed to build the generated fakes")
+	}
+	useProjectDir(t, map[string]string{
+		"go.mod": "module shop\n\ngo 1.21\n",
+		"app/domain/ports/out/ports.go": `package out
+
+import "context"
+
+type Notify func(ctx context.Context, f string) error
+
+type Apply func(f func() error, fArg int, NotifyCall string)
+
+type Find func(context.Context, string) ([]string, bool, error)
+`,
+	})
+
+	if err := EinarGenerateFakes(context.Background(), "shop"); err != nil {
+		t.Fatalf("EinarGenerateFakes() error = %v", err)
+	}
+	notify, err := os.ReadFile("app/domain/ports/out/outfake/notify.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"func(ctx context.Context, fArg string) error", "F: fArg", "F   string"} {
+		if !strings.Contains(string(notify), want) {
+			t.Errorf("notify.go = %s, want it to contain %q", notify, want)
+		}
+	}
+
+	// the fakes of ports with parameters named like the receiver must still compile
+	output, err := exec.Command("go", "vet", "./...").CombinedOutput()
+	if err != nil {
+		t.Errorf("go vet of the generated fakes failed: %v\n%s", err, output)
+	}
+}
+
+func TestEinarGenerateFakesInMemory(t *testing.T) {
+	dir := useProjectDir(t, nil)
+	useMemFileSystem(t, map[string]string{
+		"go.mod": "module shop\n\ngo 1.21\n",
+		"app/domain/ports/out/ports.go": `package out
+
+import "context"
+
+type Notify func(ctx context.Context, f string) error
+`,
+	})
+
+	if err := EinarGenerateFakes(context.Background(), "shop"); err != nil {
+		t.Fatalf("EinarGenerateFakes() error = %v", err)
+	}
+	const fake = "app/domain/ports/out/outfake/notify.go"
+	if _, err := utils.FS.ReadFile(fake); err != nil {
+		t.Errorf("ReadFile(%s) error = %v, want the fake written to the filesystem", fake, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+		t.Errorf("project folder on disk = %v, want nothing written to the disk", entries)
+	}
+}
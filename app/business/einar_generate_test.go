@@ -0,0 +1,196 @@
+package business
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// useMemFileSystem runs the test against an in-memory filesystem seeded with files.
+func useMemFileSystem(t *testing.T, files map[string]string) {
+	t.Helper()
+	previous := utils.FS
+	utils.FS = utils.NewMemFileSystem()
+	t.Cleanup(func() { utils.FS = previous })
+	for path, content := range files {
+		if err := utils.FS.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestEinarGenerate(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/templates/api/go.mod": "module github.com/acme/api\n",
+		"/templates/api/.einar.template.json": `{
+			"installation_commands": [{"name": "echo-server"}],
+			"component_commands": [
+				{"kind": "get-controller", "depends_on": ["echo-server"], "files": [{
+					"source_file": "controller.go",
+					"destination_dir": "app/adapter/in/controller",
+					"ioc_discovery": true,
+					"replace_holders": [{"kind": "PascalCase", "name": "Template"}]
+				}]},
+				{"kind": "subscription", "depends_on": ["pubsub"], "files": [{
+					"source_file": "controller.go",
+					"destination_dir": "app/adapter/in/subscription"
+				}]}
+			]
+		}`,
+		"/templates/api/controller.go": "package controller\n\nimport _ \"github.com/acme/api/app/shared/config\"\n\nfunc Template() {}\n",
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""},
+			"installations": [{"name": "echo-server", "unique": "", "libraries": null}]}`,
+		"go.mod":  "module shop\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	ctx := context.Background()
+
+	changes, err := EinarGenerate(ctx, "shop", "get-controller", "list-orders")
+	if err != nil {
+		t.Fatalf("EinarGenerate() error = %v", err)
+	}
+	wantFiles := []string{"app/adapter/in/controller/list_orders.go"}
+	if strings.Join(changes.Files, ",") != strings.Join(wantFiles, ",") {
+		t.Errorf("EinarGenerate() files = %v, want %v", changes.Files, wantFiles)
+	}
+
+	controller, err := utils.FS.ReadFile("app/adapter/in/controller/list_orders.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package controller\n\nimport _ \"shop/app/shared/config\"\n\nfunc ListOrders() {}\n"; string(controller) != want {
+		t.Errorf("generated controller = %q, want %q", controller, want)
+	}
+	imports, err := utils.ListBlankImports("main.go")
+	if err != nil || len(imports) != 1 || imports[0] != "shop/app/adapter/in/controller" {
+		t.Errorf("main.go blank imports = %v, error = %v", imports, err)
+	}
+	config, err := utils.ReadEinarCli()
+	if err != nil || len(config.Components) != 1 || config.Components[0] != (domain.Component{Kind: "get-controller", Name: "list-orders"}) {
+		t.Errorf(".einar.cli.json components = %v, error = %v", config.Components, err)
+	}
+	cliJSON, _ := utils.FS.ReadFile(".einar.cli.json")
+	if !strings.Contains(string(cliJSON), "\n  \"project\": \"shop\"") {
+		t.Errorf(".einar.cli.json = %s, want it indented like utils.CreateEinarCLIJSON", cliJSON)
+	}
+	manifest, err := utils.ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := manifest.Entry("app/adapter/in/controller/list_orders.go"); !ok {
+		t.Errorf("manifest = %v, want the generated controller recorded", manifest.Files)
+	}
+	if _, err := os.Stat("app/adapter/in/controller"); !os.IsNotExist(err) {
+		t.Errorf("EinarGenerate() wrote to disk")
+	}
+
+	_, err = EinarGenerate(ctx, "shop", "get-controller", "list-orders")
+	if domain.ErrorCodeOf(err) != domain.ErrorAlreadyExists {
+		t.Errorf("EinarGenerate() twice error = %v, want %s", err, domain.ErrorAlreadyExists)
+	}
+	_, err = EinarGenerate(ctx, "shop", "subscription", "orders")
+	if domain.ErrorCodeOf(err) != domain.ErrorDependencyMissing {
+		t.Errorf("EinarGenerate() without pubsub error = %v, want %s", err, domain.ErrorDependencyMissing)
+	}
+	_, err = EinarGenerate(ctx, "shop", "view", "orders")
+	if domain.ErrorCodeOf(err) != domain.ErrorKindUnknown {
+		t.Errorf("EinarGenerate() unknown kind error = %v, want %s", err, domain.ErrorKindUnknown)
+	}
+}
+
+func TestEinarGenerateInjectionFailure(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/templates/api/go.mod": "module github.com/acme/api\n",
+		"/templates/api/.einar.template.json": `{
+			"installation_commands": [{"name": "echo-server"}],
+			"component_commands": [
+				{"kind": "get-controller", "depends_on": ["echo-server"], "files": [{
+					"source_file": "controller.go",
+					"destination_dir": "app/adapter/in/controller"
+				}], "injections": [{
+					"destination_file": "app/router.go",
+					"marker": "routes",
+					"key": "route",
+					"snippet": "e.GET(\"/orders\", nil)"
+				}]}
+			]
+		}`,
+		"/templates/api/controller.go": "package controller\n",
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""},
+			"installations": [{"name": "echo-server", "unique": "", "libraries": null}]}`,
+		"go.mod":        "module shop\n",
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"app/router.go": "package app\n\nfunc routes() {\n}\n",
+	})
+	ctx := context.Background()
+
+	if _, err := EinarGenerate(ctx, "shop", "get-controller", "list-orders"); err == nil {
+		t.Fatal("EinarGenerate() without the routes marker succeeded")
+	}
+	config, err := utils.ReadEinarCli()
+	if err != nil || len(config.Components) != 0 {
+		t.Errorf(".einar.cli.json components = %v, error = %v, want none after a failed injection", config.Components, err)
+	}
+
+	if err := utils.FS.WriteFile("app/router.go", []byte("package app\n\nfunc routes() {\n\t// einar:inject routes\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EinarGenerate(ctx, "shop", "get-controller", "list-orders"); err != nil {
+		t.Fatalf("EinarGenerate() once the marker exists error = %v", err)
+	}
+	router, _ := utils.FS.ReadFile("app/router.go")
+	if !strings.Contains(string(router), "einar:begin get-controller/list-orders:route") {
+		t.Errorf("app/router.go = %s, want the route injected", router)
+	}
+}
+
+func TestEinarGenerateUnknownConfigField(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		".einar.cli.json": `{"schema_version": 1, "project": "shop", "projekt": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""}}`,
+	})
+
+	_, err := EinarGenerate(context.Background(), "shop", "get-controller", "list-orders")
+	if err == nil || !strings.Contains(err.Error(), "projekt") {
+		t.Errorf("EinarGenerate() error = %v, want the unknown field reported", err)
+	}
+}
+
+func TestEinarGenerateInheritedOverride(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/templates/base/go.mod": "module github.com/acme/api\n",
+		"/templates/base/.einar.template.json": `{
+			"installation_commands": [{"name": "echo-server"}],
+			"component_commands": [
+				{"kind": "get-controller", "depends_on": ["echo-server"], "files": [{
+					"source_file": "controller.go",
+					"destination_dir": "app/adapter/in/controller",
+					"replace_holders": [{"kind": "PascalCase", "name": "Template"}]
+				}]}
+			]
+		}`,
+		"/templates/base/controller.go":         "package controller\n\nfunc Template() {}\n",
+		"/templates/child/go.mod":               "module github.com/acme/api\n",
+		"/templates/child/.einar.template.json": `{"extends": {"url": "file:///templates/base", "tag": ""}}`,
+		".einar/overrides/controller.go":        "package controller\n\n// overridden\nfunc Template() {}\n",
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/child", "tag": ""},
+			"installations": [{"name": "echo-server", "unique": "", "libraries": null}]}`,
+		"go.mod":  "module shop\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+
+	if _, err := EinarGenerate(context.Background(), "shop", "get-controller", "list-orders"); err != nil {
+		t.Fatalf("EinarGenerate() error = %v", err)
+	}
+	controller, err := utils.FS.ReadFile("app/adapter/in/controller/list_orders.go")
+	if want := "package controller\n\n// overridden\nfunc ListOrders() {}\n"; err != nil || string(controller) != want {
+		t.Errorf("generated controller = %q, %v, want the override of the parent template file", controller, err)
+	}
+}
@@ -0,0 +1,132 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+var EinarImplement in.EinarImplement = func(
+	ctx context.Context,
+	project string,
+	portFile string,
+	portName string,
+	kind string) error {
+
+	cli, err := utils.ReadEinarCli()
+	if err != nil {
+		return fmt.Errorf("failed to read .einar.cli.json: %w", err)
+	}
+	resolved, err := loadTemplateLayers(cli)
+	if err != nil {
+		return fmt.Errorf("%w for project %v", err, project)
+	}
+	// the adapter is generated where the template generates the components of kind, so
+	// einar doctor and einar template extract find it like any generated component
+	component, ok := resolved.Component(kind)
+	if !ok {
+		return domain.Errorf(domain.ErrorKindUnknown, "kind %s is not declared by the project templates", kind)
+	}
+	command := GetInstallCommandWithHighestMatches(cli, component.Commands)[0]
+	var file domain.ComponentFile
+	for _, componentFile := range command.ComponentFiles {
+		if filepath.Ext(componentFile.SourceFile) == ".go" {
+			file = componentFile
+			break
+		}
+	}
+	if file.SourceFile == "" {
+		return domain.Errorf(domain.ErrorKindUnknown, "kind %s declares no go file to implement the port in", kind)
+	}
+	if !cli.HasDependency(command.DependsOn) {
+		return domain.Errorf(domain.ErrorDependencyMissing, "dependencies of %s are not present", kind)
+	}
+
+	port, err := utils.LoadPort(project, portFile, portName)
+	if err != nil {
+		return err
+	}
+
+	componentName := utils.ConvertStringCase(portName, "kebab")
+	for _, v := range cli.Components {
+		if v.Kind == kind && v.Name == componentName {
+			return domain.Errorf(domain.ErrorAlreadyExists, "the component '%s' for '%s' already exists", componentName, kind)
+		}
+	}
+	destinationPath := componentDestinationPath(file, componentName)
+	destinationDir := filepath.Dir(destinationPath)
+	if _, err := utils.FS.Stat(destinationPath); err == nil {
+		return domain.Errorf(domain.ErrorAlreadyExists, "%s already exists", destinationPath)
+	}
+
+	pkgPath := path.Join(project, filepath.ToSlash(destinationDir))
+	source := newPortSource(pkgPath)
+	source.addImport(project+"/app/shared/archetype/container", "container")
+	params, _ := source.params(port.Signature)
+
+	var returns []string
+	for i := 0; i < port.Signature.Results().Len(); i++ {
+		result := port.Signature.Results().At(i).Type()
+		if isErrorType(result) {
+			source.addImport("errors", "errors")
+			returns = append(returns, `errors.New("`+portName+` not implemented")`)
+			continue
+		}
+		returns = append(returns, source.zeroValue(result))
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "var %s %s\n\n", portName, source.portType(port))
+	body.WriteString("func init() {\n")
+	body.WriteString("\tcontainer.InjectOutboundAdapter(func() error {\n")
+	fmt.Fprintf(&body, "\t\t%s = func(%s)%s {\n", portName, params, source.results(port.Signature))
+	if len(returns) > 0 {
+		fmt.Fprintf(&body, "\t\t\treturn %s\n", strings.Join(returns, ", "))
+	}
+	body.WriteString("\t\t}\n")
+	body.WriteString("\t\treturn nil\n")
+	body.WriteString("\t})\n")
+	body.WriteString("}\n")
+
+	content := "package " + filepath.Base(destinationDir) + "\n\n" + source.importBlock() + "\n" + body.String()
+	formatted, err := format.Source([]byte(content))
+	if err != nil {
+		return fmt.Errorf("error formatting generated adapter: %w", err)
+	}
+
+	files, err := trackGeneratedFiles("component:"+kind+"/"+componentName, resolved.LayerPath(component.Layer))
+	if err != nil {
+		return err
+	}
+
+	if err := utils.FS.MkdirAll(destinationDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating directory %s: %w", destinationDir, err)
+	}
+	if err := utils.FS.WriteFile(destinationPath, formatted, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", destinationPath, err)
+	}
+	utils.Info("adapter generated", "port", portName, "path", destinationPath)
+
+	if err := utils.AddImportStatement(filepath.Join("main.go"), pkgPath); err != nil {
+		return fmt.Errorf("failed to add import statement to main.go: %w", err)
+	}
+	files.write(destinationPath)
+	files.edit(filepath.Join("main.go"))
+
+	cli.Components = append(cli.Components, domain.Component{Kind: kind, Name: componentName})
+	if err := utils.CreateEinarCLIJSON(cli); err != nil {
+		return fmt.Errorf("failed to write .einar.cli.json: %w", err)
+	}
+	if err := files.save(); err != nil {
+		return fmt.Errorf("failed to update %s: %w", domain.ManifestFile, err)
+	}
+	return nil
+}
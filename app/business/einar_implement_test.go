@@ -0,0 +1,157 @@
+package business
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// useProjectDir runs the test inside a project written to a temporary folder, for the
+// business logic that reads and writes the disk directly.
+func useProjectDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestEinarImplement(t *testing.T) {
+	dir := useProjectDir(t, map[string]string{
+		"tpl/.einar.template.json": `{"component_commands": [
+			{"kind": "repository", "depends_on": [""], "files": [{
+				"source_file": "repository.go",
+				"destination_dir": "app/adapter/out/repository",
+				"ioc_discovery": true
+			}]},
+			{"kind": "publisher", "depends_on": ["nats"], "files": [{
+				"source_file": "publisher.go",
+				"destination_dir": "app/adapter/out/publisher"
+			}]}
+		]}`,
+		"app/domain/ports/out/orders.go": `package out
+
+import "context"
+
+type SaveOrder func(ctx context.Context, id string, quantities ...int) (int64, error)
+
+type FindOrders func(context.Context, string) ([]string, bool, error)
+`,
+		"go.mod":  "module shop\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	config := domain.EinarCli{Project: "shop", Template: domain.Template{URL: utils.LocalTemplateScheme + filepath.ToSlash(filepath.Join(dir, "tpl"))}}
+	if err := utils.CreateEinarCLIJSON(config); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		port string
+		file string
+		want string
+	}{
+		{"SaveOrder", "app/adapter/out/repository/save_order.go", `package repository
+
+import (
+	"context"
+	"errors"
+
+	"shop/app/domain/ports/out"
+	"shop/app/shared/archetype/container"
+)
+
+var SaveOrder out.SaveOrder
+
+func init() {
+	container.InjectOutboundAdapter(func() error {
+		SaveOrder = func(ctx context.Context, id string, quantities ...int) (int64, error) {
+			return 0, errors.New("SaveOrder not implemented")
+		}
+		return nil
+	})
+}
+`},
+		{"FindOrders", "app/adapter/out/repository/find_orders.go", `package repository
+
+import (
+	"context"
+	"errors"
+
+	"shop/app/domain/ports/out"
+	"shop/app/shared/archetype/container"
+)
+
+var FindOrders out.FindOrders
+
+func init() {
+	container.InjectOutboundAdapter(func() error {
+		FindOrders = func(arg0 context.Context, arg1 string) ([]string, bool, error) {
+			return nil, false, errors.New("FindOrders not implemented")
+		}
+		return nil
+	})
+}
+`},
+	}
+	for _, tt := range tests {
+		if err := EinarImplement(ctx, "shop", "app/domain/ports/out/orders.go", tt.port, "repository"); err != nil {
+			t.Fatalf("EinarImplement(%s) error = %v", tt.port, err)
+		}
+		got, err := os.ReadFile(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("EinarImplement(%s) generated\n%s\nwant\n%s", tt.port, got, tt.want)
+		}
+	}
+
+	config, err := utils.ReadEinarCli()
+	if err != nil || len(config.Components) != 2 || config.Components[0] != (domain.Component{Kind: "repository", Name: "save-order"}) {
+		t.Errorf(".einar.cli.json components = %v, error = %v", config.Components, err)
+	}
+	manifest, err := utils.ReadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry, ok := manifest.Entry("app/adapter/out/repository/save_order.go"); !ok || entry.Origin != "component:repository/save-order" || entry.Tag != "tpl" {
+		t.Errorf("manifest entry = %v, %v, want the adapter recorded with the template tag", entry, ok)
+	}
+	// implemented adapters are found where einar doctor looks for components
+	report, err := EinarDoctor(ctx, false)
+	if err != nil || !report.IsHealthy() {
+		t.Errorf("EinarDoctor() = %v, %v, want no drift", report.Issues, err)
+	}
+
+	failures := []struct {
+		kind string
+		code domain.ErrorCode
+	}{
+		{"repository", domain.ErrorAlreadyExists},
+		{"publisher", domain.ErrorDependencyMissing},
+		{"view", domain.ErrorKindUnknown},
+	}
+	for _, tt := range failures {
+		err := EinarImplement(ctx, "shop", "app/domain/ports/out/orders.go", "SaveOrder", tt.kind)
+		if domain.ErrorCodeOf(err) != tt.code {
+			t.Errorf("EinarImplement() with kind %s error = %v, want %s", tt.kind, err, tt.code)
+		}
+	}
+}
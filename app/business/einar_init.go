@@ -2,10 +2,8 @@ package business
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -14,19 +12,21 @@ import (
 	"github.com/Ignaciojeria/einar/app/shared/utils"
 )
 
-var EinarInit in.EinarInit = func(ctx context.Context, templateFilePath string, project string) error {
-	if err := createInitialFilesFromTemplate(templateFilePath, project); err != nil {
-		fmt.Println(err)
-		return err
+var EinarInit in.EinarInit = func(ctx context.Context, templateFilePath string, project string) (domain.ChangeSet, error) {
+	var changes domain.ChangeSet
+	files, err := trackGeneratedFiles("init", templateFilePath)
+	if err != nil {
+		return changes, err
 	}
-	if err := createInitialDirectoriesFromTemplate(templateFilePath, project); err != nil {
-		fmt.Println(err)
-		return err
+	if err := createInitialFilesFromTemplate(templateFilePath, project, files); err != nil {
+		return changes, err
+	}
+	if err := createInitialDirectoriesFromTemplate(templateFilePath, project, files); err != nil {
+		return changes, err
 	}
 	template, err := utils.ReadEinarTemplateFromBinaryPath(templateFilePath)
 	if err != nil {
-		fmt.Println(err)
-		return err
+		return changes, err
 	}
 
 	dependencyTree := make([]string, 0)
@@ -35,14 +35,19 @@ var EinarInit in.EinarInit = func(ctx context.Context, templateFilePath string,
 		dependencyTree = append(dependencyTree, installBase.Library)
 	}
 
-	if err := initializeGoModule(dependencyTree, project); err != nil {
-		return err
+	if err := files.save(); err != nil {
+		return changes, err
+	}
+	changes.Files = files.paths()
+
+	if err := initializeGoModule(ctx, dependencyTree, project); err != nil {
+		return changes, err
 	}
 	//IMPLEMENT YOUR BUSINESS USECASE HERE
-	return nil
+	return changes, nil
 }
 
-func createInitialFilesFromTemplate(templateFilePath string, project string) error {
+func createInitialFilesFromTemplate(templateFilePath string, project string, files *generatedFiles) error {
 
 	moduleName, err := utils.ReadTemplateModuleName(templateFilePath)
 
@@ -50,21 +55,10 @@ func createInitialFilesFromTemplate(templateFilePath string, project string) err
 		return fmt.Errorf("error reading template module path")
 	}
 
-	fmt.Println(templateFilePath)
-	// Construct the path to the JSON file relative to the binary
-	jsonFilePath := filepath.Join(templateFilePath, ".einar.template.json")
-
-	// Read the JSON file content
-	jsonContentBytes, err := os.ReadFile(jsonFilePath)
-	if err != nil {
-		return fmt.Errorf("error reading JSON file: %v for project %v", err, project)
-	}
-
-	// Unmarshal the JSON content into the EinarTemplate struct
-	var template domain.EinarTemplate
-	err = json.Unmarshal(jsonContentBytes, &template)
+	utils.Debug("template folder path computed", "path", templateFilePath)
+	template, err := utils.ReadEinarTemplateFromBinaryPath(templateFilePath)
 	if err != nil {
-		return fmt.Errorf("error unmarshalling JSON file: %v for project %v", err, project)
+		return fmt.Errorf("%w for project %v", err, project)
 	}
 
 	// Extraer el tag del templateFilePath
@@ -84,30 +78,20 @@ func createInitialFilesFromTemplate(templateFilePath string, project string) err
 		// Copy the file
 		err = utils.CopyFile(sourcePath, destinationPath, []string{`"` + moduleName, "${project}", "${latest-git-tag}"}, []string{`"` + project, project, latestGitTag})
 		if err != nil {
-			return fmt.Errorf("error copying file from %s to %s: %v for project %v", sourcePath, destinationPath, err, project)
+			return fmt.Errorf("error copying file from %s to %s: %w for project %v", sourcePath, destinationPath, err, project)
 		}
+		files.write(destinationPath)
 
-		fmt.Printf("File copied successfully from %s to %s.\n", sourcePath, destinationPath)
+		utils.Info("base file copied", "source", sourcePath, "destination", destinationPath)
 	}
 
 	return nil
 }
 
-func createInitialDirectoriesFromTemplate(templateFilePath string, project string) error {
-	// Construct the path to the JSON file relative to the binary
-	jsonFilePath := filepath.Join(templateFilePath, ".einar.template.json")
-
-	// Read the JSON file content
-	jsonContentBytes, err := os.ReadFile(jsonFilePath)
-	if err != nil {
-		return fmt.Errorf("error reading JSON file: %v for project %v", err, project)
-	}
-
-	// Unmarshal the JSON content into the EinarTemplate struct
-	var template domain.EinarTemplate
-	err = json.Unmarshal(jsonContentBytes, &template)
+func createInitialDirectoriesFromTemplate(templateFilePath string, project string, files *generatedFiles) error {
+	template, err := utils.ReadEinarTemplateFromBinaryPath(templateFilePath)
 	if err != nil {
-		return fmt.Errorf("error unmarshalling JSON file: %v for project %v", err, project)
+		return fmt.Errorf("%w for project %v", err, project)
 	}
 
 	moduleName, err := utils.ReadTemplateModuleName(templateFilePath)
@@ -129,35 +113,57 @@ func createInitialDirectoriesFromTemplate(templateFilePath string, project strin
 			[]string{`"` + project, project})
 
 		if err != nil {
-			return fmt.Errorf("error copying directory from %s to %s: %v for project %v", sourceDir, destinationDir, err, project)
+			return fmt.Errorf("error copying directory from %s to %s: %w for project %v", sourceDir, destinationDir, err, project)
+		}
+		if err := files.writeDir(sourceDir, destinationDir); err != nil {
+			return err
 		}
 
-		fmt.Printf("Directory copied successfully from %s to %s.\n", sourceDir, destinationDir)
+		utils.Info("base folder copied", "source", sourceDir, "destination", destinationDir)
 	}
 
 	return nil
 }
 
-func initializeGoModule(dependencies []string, project string) error {
+func initializeGoModule(ctx context.Context, dependencies []string, project string) error {
 	// Initialize a new Go module
-	goModCmd := exec.Command("go", "mod", "init", project)
-	goModCmd.Dir = ""
-	err := goModCmd.Run()
-	if err != nil {
-		err := fmt.Errorf("error initializing go module %s", err)
-		fmt.Println(err)
+	if output, err := runGo(ctx, "", "mod", "init", project); err != nil {
+		return fmt.Errorf("error initializing go module %s\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	// go get refuses to run in a module that is not part of the workspace around it
+	if err := addModuleToGoWork(ctx); err != nil {
 		return err
 	}
 
-	goGetCmd := exec.Command("go", "get")
-	goGetCmd.Dir = ""
-	err = goGetCmd.Run()
+	if output, err := runGo(ctx, "", "get"); err != nil {
+		return fmt.Errorf("error running go get: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	// Print success message
+	utils.Info("go module generated", "module", project, "dependencies", dependencies)
+	return nil
+}
+
+// addModuleToGoWork adds the new module to the go.work file of the workspace it was
+// created in, if any.
+func addModuleToGoWork(ctx context.Context) error {
+	moduleDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	workDir, ok := utils.FindUp(filepath.Dir(moduleDir), "go.work")
+	if !ok {
+		return nil
+	}
+	rel, err := filepath.Rel(workDir, moduleDir)
 	if err != nil {
-		fmt.Println(err)
 		return err
 	}
 
-	// Print success message
-	fmt.Printf("Go module '%s' generated successfully with dependencies:", dependencies)
+	if output, err := runGo(ctx, workDir, "work", "use", "./"+filepath.ToSlash(rel)); err != nil {
+		return fmt.Errorf("error adding %s to %s: %v\n%s", rel, filepath.Join(workDir, "go.work"), err, output)
+	}
+	utils.Info("module added to workspace", "go.work", filepath.Join(workDir, "go.work"))
 	return nil
 }
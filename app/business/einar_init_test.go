@@ -1,9 +1,13 @@
 package business
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
+
+	"github.com/Ignaciojeria/einar/app/shared/utils"
 )
 
 func TestExtractTac(t *testing.T) {
@@ -17,3 +21,60 @@ func TestExtractTac(t *testing.T) {
 
 	fmt.Println(latestGitTag)
 }
+
+// useGoCommand replaces the go command with a fake that records its arguments, so tests on
+// the in-memory filesystem never touch the disk or the network.
+func useGoCommand(t *testing.T) *[]string {
+	t.Helper()
+	var calls []string
+	previous := runGo
+	runGo = func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		return nil, nil
+	}
+	t.Cleanup(func() { runGo = previous })
+	return &calls
+}
+
+func TestEinarInit(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/templates/api/v1.0.0/go.mod": "module github.com/acme/api\n",
+		"/templates/api/v1.0.0/.einar.template.json": `{
+			"base_template": {
+				"files": [{"source_file": "main.go", "destination_file": "main.go"}],
+				"folders": [{"source_dir": "app/shared", "destination_dir": "app/shared"}]
+			},
+			"installations_base": [{"name": "echo", "library": "github.com/labstack/echo/v4"}]
+		}`,
+		"/templates/api/v1.0.0/main.go":                   "package main\n\nimport _ \"github.com/acme/api/app/shared/config\"\n\n// ${latest-git-tag}\nfunc main() {}\n",
+		"/templates/api/v1.0.0/app/shared/config/conf.go": "package config\n\nconst Project = \"${project}\"\n",
+	})
+	calls := useGoCommand(t)
+
+	changes, err := EinarInit(context.Background(), "/templates/api/v1.0.0", "shop")
+	if err != nil {
+		t.Fatalf("EinarInit() error = %v", err)
+	}
+	wantFiles := []string{"main.go", "app/shared/config/conf.go"}
+	if strings.Join(changes.Files, ",") != strings.Join(wantFiles, ",") {
+		t.Errorf("EinarInit() files = %v, want %v", changes.Files, wantFiles)
+	}
+	wantContent := map[string]string{
+		"main.go":                   "package main\n\nimport _ \"shop/app/shared/config\"\n\n// v1.0.0\nfunc main() {}\n",
+		"app/shared/config/conf.go": "package config\n\nconst Project = \"shop\"\n",
+	}
+	for path, want := range wantContent {
+		if content, err := utils.FS.ReadFile(path); err != nil || string(content) != want {
+			t.Errorf("%s = %q, %v, want %q", path, content, err, want)
+		}
+	}
+	if manifest, err := utils.ReadManifest(); err != nil || len(manifest.Files) != len(wantFiles) {
+		t.Errorf("manifest = %v, %v, want the base files recorded", manifest.Files, err)
+	}
+	if want := []string{"mod init shop", "get"}; strings.Join(*calls, ",") != strings.Join(want, ",") {
+		t.Errorf("go commands = %q, want %q", *calls, want)
+	}
+	if _, err := os.Stat("app/shared/config"); !os.IsNotExist(err) {
+		t.Errorf("EinarInit() wrote to disk")
+	}
+}
@@ -2,72 +2,38 @@ package business
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/Ignaciojeria/einar/app/domain"
 	"github.com/Ignaciojeria/einar/app/domain/ports/in"
 	"github.com/Ignaciojeria/einar/app/shared/utils"
 )
 
-var EinarInstall in.EinarInstall = func(ctx context.Context, project, commandName string) error {
+var EinarInstall in.EinarInstall = func(ctx context.Context, project, commandName string) (domain.ChangeSet, error) {
+	var changes domain.ChangeSet
 
-	// read einar.cli.json
-	cliPath := filepath.Join(".einar.cli.json")
-	cliBytes, err := ioutil.ReadFile(cliPath)
+	cli, err := utils.ReadEinarCli()
 	if err != nil {
-		return fmt.Errorf("failed to read .einar.cli.json: %v", err)
+		return changes, fmt.Errorf("failed to read .einar.cli.json: %w", err)
 	}
 
-	var cli domain.EinarCli
-	err = json.Unmarshal(cliBytes, &cli)
+	resolved, err := loadTemplateLayers(cli)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal .einar.cli.json: %v", err)
+		return changes, fmt.Errorf("%w for project %v", err, project)
 	}
 
-	tagFolder := ""
-	if cli.Template.Tag != "" {
-		tagFolder = "/" + cli.Template.Tag
+	installation, ok := resolved.Installation(commandName)
+	if !ok {
+		return changes, domain.Errorf(domain.ErrorKindUnknown, "%s command not found in .einar.template.json", commandName)
 	}
+	installCommand := installation.Command
+	templateFolderPath := resolved.LayerPath(installation.Layer)
 
-	templateFolderPath, err := utils.GetTemplateFolderPath(cli.Template.URL + tagFolder)
+	files, err := trackGeneratedFiles("installation:"+commandName, templateFolderPath)
 	if err != nil {
-		return err
-	}
-
-	jsonFilePath := filepath.Join(templateFolderPath, ".einar.template.json")
-	jsonContentBytes, err := ioutil.ReadFile(jsonFilePath)
-	if err != nil {
-		utils.GitCloneTemplateInBinaryPath(cli.Template.URL, "no-auth", cli.Template.Tag)
-		jsonFilePath := filepath.Join(templateFolderPath, ".einar.template.json")
-		jsonContentBytes, err = ioutil.ReadFile(jsonFilePath)
-	}
-
-	if err != nil {
-		return fmt.Errorf("error reading JSON file: %v for project %v", err, project)
-	}
-
-	var template domain.EinarTemplate
-	err = json.Unmarshal(jsonContentBytes, &template)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling JSON file: %v for project %v", err, project)
-	}
-
-	var installCommand domain.InstallationCommand
-	for _, command := range template.InstallationCommands {
-		if command.Name == commandName {
-			installCommand = command
-			break
-		}
-	}
-
-	if installCommand.Name == "" {
-		return fmt.Errorf("%s command not found in .einar.template.json", commandName)
+		return changes, err
 	}
 
 	// Validate unique field
@@ -76,7 +42,7 @@ var EinarInstall in.EinarInstall = func(ctx context.Context, project, commandNam
 			continue // Skip empty unique values
 		}
 		if existingInstallation.Unique == installCommand.Unique {
-			return fmt.Errorf("installation with unique '%s' already exists", installCommand.Unique)
+			return changes, domain.Errorf(domain.ErrorAlreadyExists, "installation with unique '%s' already exists", installCommand.Unique)
 		}
 	}
 
@@ -100,13 +66,13 @@ var EinarInstall in.EinarInstall = func(ctx context.Context, project, commandNam
 	for _, dependency := range installCommand.DependsOn {
 		if !installationsMap[dependency] {
 			dependsOn = append(dependsOn, dependency)
-			fmt.Println("einar install " + dependency)
+			utils.Warn("missing dependency, run einar install "+dependency, "installation", commandName, "dependency", dependency)
 		}
 	}
 
 	// Devolver error si hay dependencias faltantes
 	if len(dependsOn) > 0 {
-		return errors.New("dependencies are not present")
+		return changes, domain.Errorf(domain.ErrorDependencyMissing, "dependencies are not present")
 	}
 
 	placeHolders := []string{`"archetype`, "${project}"}
@@ -114,129 +80,116 @@ var EinarInstall in.EinarInstall = func(ctx context.Context, project, commandNam
 	for _, folder := range installCommand.Folders {
 		sourceDir := filepath.Join(templateFolderPath, folder.SourceDir)
 		destDir := filepath.Join( /*project*/ "", folder.DestinationDir)
+		utils.Debug("installation folder paths computed", "installation", commandName, "source", sourceDir, "destination", destDir)
 
 		err = utils.CopyDirectory(sourceDir, destDir, placeHolders, placeHoldersReplace)
 		if err != nil {
-			return fmt.Errorf("error cloning %s directory: %v", commandName, err)
+			return changes, fmt.Errorf("error cloning %s directory: %w", commandName, err)
+		}
+		if err := files.writeDir(sourceDir, destDir); err != nil {
+			return changes, err
+		}
+		for _, override := range resolved.OverridesIn(installation.Layer, folder.SourceDir) {
+			overrideDest := filepath.Join(destDir, strings.TrimPrefix(override, resolved.OverrideKey(installation.Layer, folder.SourceDir)+"/"))
+			err = utils.CopyFile(resolved.SourcePath(installation.Layer, override), overrideDest, placeHolders, placeHoldersReplace)
+			if err != nil {
+				return changes, fmt.Errorf("error copying override %s: %w", override, err)
+			}
+			files.write(overrideDest)
 		}
 
-		fmt.Printf("%s directory cloned successfully to %s.\n", commandName, destDir)
+		utils.Info("installation folder copied", "installation", commandName, "destination", destDir)
 
 		if !folder.IocDiscovery {
 			continue
 		}
 
 		setupFilePath := filepath.Join( /*project*/ "", "main.go")
+		files.edit(setupFilePath)
 
-		err = utils.AddImportStatement(setupFilePath, fmt.Sprintf(project+"/"+folder.SourceDir))
+		err = utils.AddImportStatement(setupFilePath, fmt.Sprintf(project+"/"+folder.DestinationDir))
 		if err != nil {
-			return fmt.Errorf("failed to add import statement to setup.go: %v", err)
+			return changes, fmt.Errorf("failed to add import statement to setup.go: %w", err)
 		}
+		changes.Imports = append(changes.Imports, project+"/"+folder.DestinationDir)
 
 		firstLevelDirs, err := utils.ListFirstLevelDirs(sourceDir)
 		if err != nil {
-			return fmt.Errorf("failed to list first level directories: %v", err)
+			return changes, fmt.Errorf("failed to list first level directories: %w", err)
 		}
 
 		for _, v := range firstLevelDirs {
-			err = utils.AddImportStatement(setupFilePath, fmt.Sprintf(project+"/"+folder.SourceDir+"/"+v))
+			err = utils.AddImportStatement(setupFilePath, fmt.Sprintf(project+"/"+folder.DestinationDir+"/"+v))
 			if err != nil {
-				return fmt.Errorf("failed to add import statement to setup.go: %v", err)
+				return changes, fmt.Errorf("failed to add import statement to setup.go: %w", err)
 			}
+			changes.Imports = append(changes.Imports, project+"/"+folder.DestinationDir+"/"+v)
 		}
 	}
 
 	for _, file := range installCommand.Files {
 
-		sourceDir := filepath.Join(templateFolderPath, file.SourceFile)
+		sourceDir := resolved.SourcePath(installation.Layer, file.SourceFile)
 		destDir := filepath.Join( /*project*/ "", file.DestinationDir+"/"+filepath.Base(file.SourceFile))
+		utils.Debug("installation file paths computed", "installation", commandName, "source", sourceDir, "destination", destDir)
 
 		err = utils.CopyFile(sourceDir, destDir, placeHolders, placeHoldersReplace)
 		if err != nil {
-			return fmt.Errorf("error cloning %s directory: %v", commandName, err)
+			return changes, fmt.Errorf("error cloning %s directory: %w", commandName, err)
 		}
+		files.write(destDir)
 
-		fmt.Printf("%s directory cloned successfully to %s.\n", commandName, destDir)
+		utils.Info("installation file copied", "installation", commandName, "destination", destDir)
 
 		if !file.IocDiscovery {
 			continue
 		}
 
 		setupFilePath := filepath.Join( /*project*/ "", "main.go")
+		files.edit(setupFilePath)
 
 		err = utils.AddImportStatement(setupFilePath, fmt.Sprintf(project+"/"+file.DestinationDir))
 		if err != nil {
-			return fmt.Errorf("failed to add import statement to setup.go: %v", err)
+			return changes, fmt.Errorf("failed to add import statement to setup.go: %w", err)
 		}
+		changes.Imports = append(changes.Imports, project+"/"+file.DestinationDir)
 	}
 
-	if err := addInstallationInsideCli( /*"project"*/ "", commandName); err != nil {
-		return fmt.Errorf("failed to update .einar.template.json: %v", err)
-	}
-
-	cmd := exec.Command("go", "get")
-	cmd.Dir = ""
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err = cmd.Run()
-	if err != nil {
-		return fmt.Errorf("error execugint go get for %s : %v", commandName, err)
+	if err := applyInjections(resolved, installation.Layer, commandName, "", installCommand.Injections, placeHolders, placeHoldersReplace); err != nil {
+		return changes, err
 	}
-
-	return nil
-}
-
-func addInstallationInsideCli(project, commandName string) error {
-
-	// read einar.cli.json
-	cliPath := filepath.Join(".einar.cli.json")
-	cliBytes, err := ioutil.ReadFile(cliPath)
-	if err != nil {
-		return fmt.Errorf("failed to read .einar.cli.json: %v", err)
+	for _, injection := range installCommand.Injections {
+		files.edit(injection.DestinationFile)
 	}
 
-	var cli domain.EinarCli
-	err = json.Unmarshal(cliBytes, &cli)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal .einar.cli.json: %v", err)
+	if err := addInstallationInsideCli( /*"project"*/ "", installCommand); err != nil {
+		return changes, fmt.Errorf("failed to update .einar.template.json: %w", err)
 	}
 
-	tagFolder := ""
-	if cli.Template.Tag != "" {
-		tagFolder = "/" + cli.Template.Tag
+	if err := files.save(); err != nil {
+		return changes, fmt.Errorf("failed to update %s: %w", domain.ManifestFile, err)
 	}
+	changes.Files = files.paths()
+	changes.Installations = append(changes.Installations, domain.Installation{
+		Name:      installCommand.Name,
+		Libraries: installCommand.Libraries,
+		Unique:    installCommand.Unique,
+	})
+	changes.Warnings = resolved.Warnings
 
-	templateFolderPath, err := utils.GetTemplateFolderPath(cli.Template.URL + tagFolder)
+	// go get reports its failures in its output, which is only shown when verbose
+	output, err := runGo(ctx, "", "get")
 	if err != nil {
-		return err
+		return changes, fmt.Errorf("error execugint go get for %s : %w\n%s", commandName, err, strings.TrimSpace(string(output)))
 	}
 
-	// read einar.template.json
-	templatePath := filepath.Join(templateFolderPath, ".einar.template.json")
-
-	fmt.Println(templatePath)
-	templateBytes, err := ioutil.ReadFile(templatePath)
-	if err != nil {
-		return fmt.Errorf("failed to read .einar.template.json: %v", err)
-	}
+	return changes, nil
+}
 
-	var template domain.EinarTemplate
-	err = json.Unmarshal(templateBytes, &template)
+func addInstallationInsideCli(project string, command domain.InstallationCommand) error {
+	cli, err := utils.ReadEinarCli()
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal .einar.template.json: %v", err)
-	}
-
-	// find the command
-	var command domain.InstallationCommand
-	for _, cmd := range template.InstallationCommands {
-		if cmd.Name == commandName {
-			command = cmd
-			break
-		}
-	}
-
-	if command.Name == "" {
-		return fmt.Errorf("command %s not found in .einar.template.json", commandName)
+		return fmt.Errorf("failed to read .einar.cli.json: %w", err)
 	}
 
 	// add the command to the CLI
@@ -247,14 +200,8 @@ func addInstallationInsideCli(project, commandName string) error {
 	})
 
 	// write back the updated einar.cli.json
-	cliBytes, err = json.MarshalIndent(cli, "", "    ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal .einar.cli.json: %v", err)
-	}
-
-	err = ioutil.WriteFile(cliPath, cliBytes, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write .einar.cli.json: %v", err)
+	if err := utils.CreateEinarCLIJSON(cli); err != nil {
+		return fmt.Errorf("failed to write .einar.cli.json: %w", err)
 	}
 
 	return nil
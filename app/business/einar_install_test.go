@@ -0,0 +1,103 @@
+package business
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func TestEinarInstallImportsDestinationFolders(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is needed to run go get after installing")
+	}
+	dir := useProjectDir(t, map[string]string{
+		"tpl/.einar.template.json": `{"installation_commands": [{"name": "config", "folders": [{
+			"source_dir": "inst/config",
+			"destination_dir": "app/shared/config",
+			"ioc_discovery": true
+		}]}]}`,
+		"tpl/inst/config/config.go":  "package config\n",
+		"tpl/inst/config/env/env.go": "package env\n",
+		"go.mod":                     "module shop\n",
+		"main.go":                    "package main\n\nfunc main() {}\n",
+	})
+	config := domain.EinarCli{Project: "shop", Template: domain.Template{URL: utils.LocalTemplateScheme + filepath.ToSlash(filepath.Join(dir, "tpl"))}}
+	if err := utils.CreateEinarCLIJSON(config); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := EinarInstall(context.Background(), "shop", "config")
+	if err != nil {
+		t.Fatalf("EinarInstall() error = %v", err)
+	}
+	want := []string{"shop/app/shared/config", "shop/app/shared/config/env"}
+	if strings.Join(changes.Imports, ",") != strings.Join(want, ",") {
+		t.Errorf("EinarInstall() imports = %v, want %v", changes.Imports, want)
+	}
+	imports, err := utils.ListBlankImports("main.go")
+	if err != nil || strings.Join(imports, ",") != strings.Join(want, ",") {
+		t.Errorf("main.go blank imports = %v, error = %v, want %v", imports, err, want)
+	}
+}
+
+func TestEinarInstall(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/templates/api/.einar.template.json": `{"installation_commands": [
+			{"name": "echo-server", "libraries": ["github.com/labstack/echo/v4"], "files": [{
+				"source_file": "inst/server.go",
+				"destination_dir": "app/infrastructure/server",
+				"ioc_discovery": true
+			}]},
+			{"name": "postgres", "depends_on": ["sql"]}
+		]}`,
+		"/templates/api/inst/server.go": "package server\n\nimport _ \"archetype/app/shared/config\"\n",
+		".einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""}}`,
+		"go.mod":  "module shop\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	calls := useGoCommand(t)
+	ctx := context.Background()
+
+	changes, err := EinarInstall(ctx, "shop", "echo-server")
+	if err != nil {
+		t.Fatalf("EinarInstall() error = %v", err)
+	}
+	wantFiles := []string{"app/infrastructure/server/server.go"}
+	if strings.Join(changes.Files, ",") != strings.Join(wantFiles, ",") {
+		t.Errorf("EinarInstall() files = %v, want %v", changes.Files, wantFiles)
+	}
+	server, err := utils.FS.ReadFile("app/infrastructure/server/server.go")
+	if want := "package server\n\nimport _ \"shop/app/shared/config\"\n"; err != nil || string(server) != want {
+		t.Errorf("installed server = %q, %v, want %q", server, err, want)
+	}
+	imports, err := utils.ListBlankImports("main.go")
+	if err != nil || len(imports) != 1 || imports[0] != "shop/app/infrastructure/server" {
+		t.Errorf("main.go blank imports = %v, error = %v", imports, err)
+	}
+	config, err := utils.ReadEinarCli()
+	if err != nil || len(config.Installations) != 1 || config.Installations[0].Name != "echo-server" {
+		t.Errorf(".einar.cli.json installations = %v, error = %v", config.Installations, err)
+	}
+	if want := []string{"get"}; strings.Join(*calls, ",") != strings.Join(want, ",") {
+		t.Errorf("go commands = %q, want %q", *calls, want)
+	}
+	if _, err := os.Stat("app/infrastructure/server"); !os.IsNotExist(err) {
+		t.Errorf("EinarInstall() wrote to disk")
+	}
+
+	_, err = EinarInstall(ctx, "shop", "postgres")
+	if domain.ErrorCodeOf(err) != domain.ErrorDependencyMissing {
+		t.Errorf("EinarInstall() without sql error = %v, want %s", err, domain.ErrorDependencyMissing)
+	}
+	_, err = EinarInstall(ctx, "shop", "redis")
+	if domain.ErrorCodeOf(err) != domain.ErrorKindUnknown {
+		t.Errorf("EinarInstall() unknown installation error = %v, want %s", err, domain.ErrorKindUnknown)
+	}
+}
@@ -0,0 +1,20 @@
+package business
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// EinarMigrateConfig upgrades the .einar.cli.json file of the current project to the
+// current schema version and checks the result decodes strictly.
+var EinarMigrateConfig in.EinarMigrateConfig = func(ctx context.Context) (domain.ConfigMigration, error) {
+	migration, err := utils.MigrateEinarCli(".einar.cli.json")
+	if err != nil {
+		return migration, err
+	}
+	_, err = utils.ReadEinarCli()
+	return migration, err
+}
@@ -0,0 +1,64 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// EinarPlugins lists the plugin executables found on PATH. A plugin found again further
+// down PATH is shadowed by the first one.
+var EinarPlugins in.EinarPlugins = func(ctx context.Context) ([]domain.Plugin, error) {
+	var plugins []domain.Plugin
+	found := make(map[string]string)
+	for _, path := range utils.ListExecutables(domain.PluginPrefix) {
+		name := strings.TrimPrefix(filepath.Base(path), domain.PluginPrefix)
+		if runtime.GOOS == "windows" {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+		plugin := domain.Plugin{Name: name, Path: path, ShadowedBy: found[name]}
+		if plugin.ShadowedBy == "" {
+			found[name] = path
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, nil
+}
+
+// EinarPluginEnv returns the environment variables describing the project holding dir to
+// the plugins: its root, its parsed .einar.cli.json and the folders of its template layers,
+// base layer first. Outside projects there are none.
+var EinarPluginEnv in.EinarPluginEnv = func(ctx context.Context, dir string) ([]string, error) {
+	root, ok := utils.FindUp(dir, ".einar.cli.json")
+	if !ok {
+		return nil, nil
+	}
+	cli, err := utils.ReadEinarCliFile(filepath.Join(root, ".einar.cli.json"))
+	if err != nil {
+		return nil, err
+	}
+	cliJSON, err := json.Marshal(cli)
+	if err != nil {
+		return nil, err
+	}
+	var templatePaths []string
+	for _, template := range cli.TemplateLayers() {
+		templatePath, err := utils.TemplateFolderPath(template)
+		if err != nil {
+			return nil, err
+		}
+		templatePaths = append(templatePaths, templatePath)
+	}
+	return []string{
+		"EINAR_PROJECT_ROOT=" + root,
+		"EINAR_CLI_JSON=" + string(cliJSON),
+		"EINAR_TEMPLATE_PATH=" + strings.Join(templatePaths, string(os.PathListSeparator)),
+	}, nil
+}
@@ -0,0 +1,76 @@
+package business
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestEinarPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are recognized by PATHEXT on windows")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	for path, mode := range map[string]os.FileMode{
+		filepath.Join(first, "einar-lint"):  0755,
+		filepath.Join(first, "einar-notes"): 0644,
+		filepath.Join(second, "einar-lint"): 0755,
+		filepath.Join(second, "einar-fmt"):  0755,
+		filepath.Join(second, "other-tool"): 0755,
+	} {
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	plugins, err := EinarPlugins(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.Plugin{
+		{Name: "lint", Path: filepath.Join(first, "einar-lint")},
+		{Name: "fmt", Path: filepath.Join(second, "einar-fmt")},
+		{Name: "lint", Path: filepath.Join(second, "einar-lint"), ShadowedBy: filepath.Join(first, "einar-lint")},
+	}
+	if len(plugins) != len(want) {
+		t.Fatalf("EinarPlugins() = %v, want %v", plugins, want)
+	}
+	for i := range want {
+		if plugins[i] != want[i] {
+			t.Errorf("EinarPlugins()[%d] = %v, want %v", i, plugins[i], want[i])
+		}
+	}
+}
+
+func TestEinarPluginEnv(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/work/shop/.einar.cli.json": `{"schema_version": 1, "project": "shop",
+			"template": {"url": "file:///templates/api", "tag": ""}}`,
+	})
+
+	env, err := EinarPluginEnv(context.Background(), "/work/shop/app/adapter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(env, "\n")
+	for _, want := range []string{
+		"EINAR_PROJECT_ROOT=" + filepath.FromSlash("/work/shop"),
+		`EINAR_CLI_JSON={"schema_version":1,"project":"shop"`,
+		"EINAR_TEMPLATE_PATH=" + filepath.FromSlash("/templates/api"),
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("EinarPluginEnv() = %v, want it to contain %s", env, want)
+		}
+	}
+
+	env, err = EinarPluginEnv(context.Background(), "/work")
+	if err != nil || len(env) != 0 {
+		t.Errorf("EinarPluginEnv() outside a project = %v, %v, want none", env, err)
+	}
+}
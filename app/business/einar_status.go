@@ -0,0 +1,20 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// EinarStatus reports whether each file recorded in the manifest of the current project
+// is pristine, was modified or was deleted since einar generated it.
+var EinarStatus in.EinarStatus = func(ctx context.Context) ([]domain.FileStatus, error) {
+	manifest, err := utils.ReadManifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", domain.ManifestFile, err)
+	}
+	return fileStatuses(manifest), nil
+}
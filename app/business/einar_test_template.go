@@ -0,0 +1,194 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+const templateTestProject = "einar-template-test"
+
+var EinarTestTemplate in.EinarTestTemplate = func(ctx context.Context, templateDir string) (domain.TemplateTestReport, error) {
+	var report domain.TemplateTestReport
+
+	templateDir, err := filepath.Abs(templateDir)
+	if err != nil {
+		return report, err
+	}
+	template, err := utils.ReadEinarTemplateFromBinaryPath(templateDir)
+	if err != nil {
+		return report, err
+	}
+
+	for _, scenario := range planTemplateScenarios(template) {
+		if err := runTemplateScenario(ctx, templateDir, &scenario); err != nil {
+			return report, err
+		}
+		report.Scenarios = append(report.Scenarios, scenario)
+	}
+	return report, nil
+}
+
+// planTemplateScenarios returns one scenario per distinct set of installations needed to
+// install every installation command and to generate every component kind variant. A
+// variant depending on alternatives gets a scenario for each alternative.
+func planTemplateScenarios(template domain.EinarTemplate) []domain.TemplateTestScenario {
+	var scenarios []domain.TemplateTestScenario
+	index := make(map[string]int)
+	add := func(installations []string, kind string) {
+		key := strings.Join(installations, ",")
+		i, ok := index[key]
+		if !ok {
+			i = len(scenarios)
+			index[key] = i
+			scenarios = append(scenarios, domain.TemplateTestScenario{Installations: installations})
+		}
+		if kind != "" && !utils.Contains(scenarios[i].Kinds, kind) {
+			scenarios[i].Kinds = append(scenarios[i].Kinds, kind)
+		}
+	}
+
+	for _, command := range template.InstallationCommands {
+		add(installationClosure(template, command.Name), "")
+	}
+
+	for _, command := range template.ComponentCommands {
+		if len(command.DependsOn) == 0 || utils.Contains(command.DependsOn, "") {
+			add(nil, command.Kind)
+			continue
+		}
+		for _, dependency := range command.DependsOn {
+			for _, candidate := range installationCandidates(template, dependency) {
+				add(installationClosure(template, candidate), command.Kind)
+			}
+		}
+	}
+	return scenarios
+}
+
+// installationCandidates returns the installations matching dependency by name or unique.
+func installationCandidates(template domain.EinarTemplate, dependency string) []string {
+	for _, command := range template.InstallationCommands {
+		if command.Name == dependency {
+			return []string{command.Name}
+		}
+	}
+	var candidates []string
+	for _, command := range template.InstallationCommands {
+		if command.Unique != "" && command.Unique == dependency {
+			candidates = append(candidates, command.Name)
+		}
+	}
+	return candidates
+}
+
+// installationClosure returns name preceded by the installations it depends on, in install order.
+func installationClosure(template domain.EinarTemplate, name string) []string {
+	var order []string
+	visiting := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		if visiting[name] {
+			return
+		}
+		visiting[name] = true
+		for _, command := range template.InstallationCommands {
+			if command.Name != name {
+				continue
+			}
+			for _, dependency := range command.DependsOn {
+				candidates := installationCandidates(template, dependency)
+				if len(candidates) > 0 && !containsAny(order, candidates) {
+					visit(candidates[0])
+				}
+			}
+			break
+		}
+		order = append(order, name)
+	}
+	visit(name)
+	return order
+}
+
+func runTemplateScenario(ctx context.Context, templateDir string, scenario *domain.TemplateTestScenario) error {
+	workspace, err := os.MkdirTemp("", "einar-template-test-")
+	if err != nil {
+		return fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(workspace)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(workspace); err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+
+	fail := func(step, target, output string) {
+		scenario.Failures = append(scenario.Failures, domain.TemplateTestFailure{Step: step, Target: target, Output: output})
+	}
+
+	if _, err := EinarInit(ctx, templateDir, templateTestProject); err != nil {
+		fail("init", "", err.Error())
+		return nil
+	}
+	err = utils.CreateEinarCLIJSON(domain.EinarCli{
+		Project:  templateTestProject,
+		Template: domain.Template{URL: utils.LocalTemplateScheme + filepath.ToSlash(templateDir)},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating einar cli file: %w", err)
+	}
+
+	for _, installation := range scenario.Installations {
+		if _, err := EinarInstall(ctx, templateTestProject, installation); err != nil {
+			fail("install", installation, err.Error())
+			return nil
+		}
+	}
+
+	verify := func(target string) bool {
+		for _, step := range []string{"build", "vet"} {
+			output, err := runGo(ctx, "", step, "./...")
+			if err != nil {
+				fail(step, target, string(output))
+				return false
+			}
+		}
+		return true
+	}
+
+	if !verify(strings.Join(scenario.Installations, ", ")) {
+		return nil
+	}
+
+	// Every kind is verified right after it is generated so a failure points to it.
+	// The scenario stops at the first failing kind since the ones after it would not build either.
+	for _, kind := range scenario.Kinds {
+		if _, err := EinarGenerate(ctx, templateTestProject, kind, kind+"-sample"); err != nil {
+			fail("generate", kind, err.Error())
+			return nil
+		}
+		if !verify(kind) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func containsAny(names []string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if utils.Contains(names, candidate) {
+			return true
+		}
+	}
+	return false
+}
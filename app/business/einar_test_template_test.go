@@ -0,0 +1,37 @@
+package business
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestPlanTemplateScenarios(t *testing.T) {
+	template := domain.EinarTemplate{
+		InstallationCommands: []domain.InstallationCommand{
+			{Name: "echo-server", Unique: "http-server"},
+			{Name: "gin-server", Unique: "http-server"},
+			{Name: "pubsub"},
+			{Name: "firestore", DependsOn: []string{"pubsub"}},
+		},
+		ComponentCommands: []domain.ComponentCommands{
+			{Kind: "get-controller", DependsOn: []string{"http-server"}},
+			{Kind: "firestore-repository", DependsOn: []string{"firestore"}},
+			{Kind: "usecase", DependsOn: []string{""}},
+		},
+	}
+
+	want := []domain.TemplateTestScenario{
+		{Installations: []string{"echo-server"}, Kinds: []string{"get-controller"}},
+		{Installations: []string{"gin-server"}, Kinds: []string{"get-controller"}},
+		{Installations: []string{"pubsub"}},
+		{Installations: []string{"pubsub", "firestore"}, Kinds: []string{"firestore-repository"}},
+		{Kinds: []string{"usecase"}},
+	}
+
+	got := planTemplateScenarios(template)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("planTemplateScenarios() = %+v, want %+v", got, want)
+	}
+}
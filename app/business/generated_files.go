@@ -0,0 +1,129 @@
+package business
+
+import (
+	"path/filepath"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// generatedFiles collects the files an einar command writes and edits to record them in
+// the manifest once the command succeeds.
+type generatedFiles struct {
+	origin   string
+	tag      string
+	manifest domain.Manifest
+	pristine map[string]bool
+	written  []string
+	edited   []string
+}
+
+// trackGeneratedFiles starts tracking the files written for origin from the template
+// cached in templateFolderPath, empty for files einar writes without a template. The files
+// that are still pristine are remembered, so the files einar edits afterwards keep their
+// status.
+func trackGeneratedFiles(origin, templateFolderPath string) (*generatedFiles, error) {
+	manifest, err := utils.ReadManifest()
+	if err != nil {
+		return nil, err
+	}
+	files := &generatedFiles{
+		origin:   origin,
+		manifest: manifest,
+		pristine: make(map[string]bool),
+	}
+	if templateFolderPath != "" {
+		files.tag = filepath.Base(templateFolderPath)
+	}
+	for _, status := range fileStatuses(manifest) {
+		files.pristine[status.Path] = status.Status == domain.FilePristine
+	}
+	return files, nil
+}
+
+// write records paths as generated by the tracked command.
+func (g *generatedFiles) write(paths ...string) {
+	g.written = append(g.written, paths...)
+}
+
+// writeDir records the files found below srcDir as generated in dstDir.
+func (g *generatedFiles) writeDir(srcDir, dstDir string) error {
+	files, err := utils.ListFiles(srcDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		g.write(filepath.Join(dstDir, file))
+	}
+	return nil
+}
+
+// paths returns the slash separated paths of the written files.
+func (g *generatedFiles) paths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, path := range g.written {
+		path = filepath.ToSlash(filepath.Clean(path))
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// edit records paths as edited by the tracked command, such as main.go.
+func (g *generatedFiles) edit(paths ...string) {
+	g.edited = append(g.edited, paths...)
+}
+
+// save records the hashes of the written files, and of the edited files that were
+// pristine, in the manifest.
+func (g *generatedFiles) save() error {
+	for _, path := range g.written {
+		if err := g.record(path, g.origin, g.tag); err != nil {
+			return err
+		}
+	}
+	for _, path := range g.edited {
+		entry, ok := g.manifest.Entry(filepath.ToSlash(filepath.Clean(path)))
+		if !ok || !g.pristine[entry.Path] {
+			continue
+		}
+		if err := g.record(path, entry.Origin, entry.Tag); err != nil {
+			return err
+		}
+	}
+	return utils.WriteManifest(g.manifest)
+}
+
+func (g *generatedFiles) record(path, origin, tag string) error {
+	sum, err := utils.HashFile(path)
+	if err != nil {
+		return err
+	}
+	g.manifest.Record(domain.ManifestEntry{
+		Path:   filepath.ToSlash(filepath.Clean(path)),
+		Origin: origin,
+		Tag:    tag,
+		SHA256: sum,
+	})
+	return nil
+}
+
+// fileStatuses compares the files recorded in manifest with the project files.
+func fileStatuses(manifest domain.Manifest) []domain.FileStatus {
+	statuses := make([]domain.FileStatus, 0, len(manifest.Files))
+	for _, entry := range manifest.Files {
+		status := domain.FilePristine
+		sum, err := utils.HashFile(filepath.FromSlash(entry.Path))
+		switch {
+		case err != nil:
+			status = domain.FileDeleted
+		case sum != entry.SHA256:
+			status = domain.FileModified
+		}
+		statuses = append(statuses, domain.FileStatus{Path: entry.Path, Origin: entry.Origin, Status: status})
+	}
+	return statuses
+}
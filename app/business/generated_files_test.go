@@ -0,0 +1,69 @@
+package business
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestGeneratedFiles(t *testing.T) {
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	writeFile := func(path, content string) {
+		os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	initFiles, err := trackGeneratedFiles("init", "/cache/github.com/x/tpl/v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile("main.go", "package main\n")
+	writeFile("README.md", "# app\n")
+	initFiles.write("main.go", "README.md")
+	if err := initFiles.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The user edits the README, then generate writes a component and edits main.go.
+	writeFile("README.md", "# my app\n")
+	generate, err := trackGeneratedFiles("component:get-controller/customer", "/cache/github.com/x/tpl/v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile("app/adapter/in/controller/customer.go", "package controller\n")
+	writeFile("main.go", "package main\n\nimport _ \"app/adapter/in/controller\"\n")
+	generate.write(filepath.Join("app", "adapter", "in", "controller", "customer.go"))
+	generate.edit("main.go", "README.md")
+	if err := generate.save(); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(filepath.Join("app", "adapter", "in", "controller", "customer.go"))
+
+	statuses, err := EinarStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.FileStatus{
+		{Path: "README.md", Origin: "init", Status: domain.FileModified},
+		{Path: "app/adapter/in/controller/customer.go", Origin: "component:get-controller/customer", Status: domain.FileDeleted},
+		{Path: "main.go", Origin: "init", Status: domain.FilePristine},
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("EinarStatus() = %+v, want %+v", statuses, want)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Errorf("EinarStatus()[%d] = %+v, want %+v", i, statuses[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package business
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// injectionKey scopes a template injection key to the installation or component that owns it,
+// so the same key can be used to remove the block again.
+func injectionKey(owner string, injection domain.Injection) string {
+	return owner + ":" + injection.Key
+}
+
+func applyInjections(
+	resolved domain.ResolvedTemplate,
+	layer int,
+	owner string,
+	componentName string,
+	injections []domain.Injection,
+	placeHolders []string,
+	placeHoldersReplace []string) error {
+	for _, injection := range injections {
+		snippet := injection.Snippet
+		if injection.SourceFile != "" {
+			snippetBytes, err := utils.FS.ReadFile(resolved.SourcePath(layer, injection.SourceFile))
+			if err != nil {
+				return fmt.Errorf("error reading injection source file %s: %w", injection.SourceFile, err)
+			}
+			snippet = string(snippetBytes)
+		}
+
+		holders := append([]string{}, placeHolders...)
+		values := append([]string{}, placeHoldersReplace...)
+		for _, v := range injection.ReplaceHolders {
+			holders = append(holders, v.Name)
+			values = append(values, v.AppendAtStart+utils.ConvertStringCase(componentName, v.Kind)+v.AppendAtEnd)
+		}
+		for _, v := range injection.LiteralReplacements {
+			holders = append(holders, v.Target)
+			values = append(values, v.Replacement)
+		}
+		for i, holder := range holders {
+			snippet = strings.ReplaceAll(snippet, holder, values[i])
+		}
+
+		key := injectionKey(owner, injection)
+		if err := utils.InjectSnippet(injection.DestinationFile, injection.Marker, key, snippet); err != nil {
+			return fmt.Errorf("error injecting %s into %s: %v", key, injection.DestinationFile, err)
+		}
+		utils.Info("snippet injected", "key", key, "file", injection.DestinationFile)
+	}
+	return nil
+}
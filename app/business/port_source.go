@@ -0,0 +1,154 @@
+package business
+
+import (
+	"fmt"
+	"go/build"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// portSource renders Go source for types referenced by a port and collects the imports they need.
+type portSource struct {
+	pkgPath string
+	imports map[string]string
+}
+
+func newPortSource(pkgPath string) *portSource {
+	return &portSource{pkgPath: pkgPath, imports: make(map[string]string)}
+}
+
+func (s *portSource) qualifier(pkg *types.Package) string {
+	if pkg.Path() == s.pkgPath {
+		return ""
+	}
+	s.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (s *portSource) addImport(path, name string) {
+	s.imports[path] = name
+}
+
+func (s *portSource) typeString(t types.Type) string {
+	return types.TypeString(t, s.qualifier)
+}
+
+func (s *portSource) portType(port utils.Port) string {
+	return s.typeString(port.Package.Scope().Lookup(port.Name).Type())
+}
+
+// importBlock returns the import declaration for every package referenced so far.
+func (s *portSource) importBlock() string {
+	if len(s.imports) == 0 {
+		return ""
+	}
+	paths := make([]string, 0, len(s.imports))
+	for path := range s.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var std, others []string
+	for _, path := range paths {
+		if !isStdPath(path) {
+			others = append(others, "\t"+strconv.Quote(path)+"\n")
+			continue
+		}
+		std = append(std, "\t"+strconv.Quote(path)+"\n")
+	}
+	groups := strings.Join(std, "")
+	if len(std) > 0 && len(others) > 0 {
+		groups += "\n"
+	}
+	groups += strings.Join(others, "")
+	return "import (\n" + groups + ")\n"
+}
+
+// params renders the parameter list of signature, naming unnamed parameters arg0, arg1...
+// Parameters named like one of the reserved identifiers the generated body refers to get
+// an Arg suffix, so they do not shadow them.
+func (s *portSource) params(signature *types.Signature, reserved ...string) (string, []string) {
+	taken := make(map[string]bool)
+	for _, name := range reserved {
+		taken[name] = true
+	}
+	for i := 0; i < signature.Params().Len(); i++ {
+		taken[signature.Params().At(i).Name()] = true
+	}
+	var parts, names []string
+	for i := 0; i < signature.Params().Len(); i++ {
+		param := signature.Params().At(i)
+		name := param.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		for _, reservedName := range reserved {
+			if name != reservedName {
+				continue
+			}
+			for taken[name] {
+				name += "Arg"
+			}
+			taken[name] = true
+		}
+		typeString := s.typeString(param.Type())
+		if signature.Variadic() && i == signature.Params().Len()-1 {
+			typeString = "..." + s.typeString(param.Type().(*types.Slice).Elem())
+		}
+		parts = append(parts, name+" "+typeString)
+		names = append(names, name)
+	}
+	return strings.Join(parts, ", "), names
+}
+
+// results renders the result list of signature.
+func (s *portSource) results(signature *types.Signature) string {
+	var parts []string
+	for i := 0; i < signature.Results().Len(); i++ {
+		parts = append(parts, s.typeString(signature.Results().At(i).Type()))
+	}
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return " " + parts[0]
+	default:
+		return " (" + strings.Join(parts, ", ") + ")"
+	}
+}
+
+// zeroValue renders the zero value of t.
+func (s *portSource) zeroValue(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsNumeric != 0:
+			if _, named := t.(*types.Named); named {
+				return s.typeString(t) + "(0)"
+			}
+			return "0"
+		}
+		return "nil"
+	case *types.Struct, *types.Array:
+		return s.typeString(t) + "{}"
+	default:
+		return "nil"
+	}
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isStdPath reports whether path belongs to the standard library.
+func isStdPath(path string) bool {
+	pkg, err := build.Import(path, "", build.FindOnly)
+	return err == nil && pkg.Goroot
+}
@@ -0,0 +1,23 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// runGo runs the go command with args in dir, the working directory when empty, and returns
+// its output, which is also logged when verbose. The go command works on the disk, so tests
+// running einar on the in-memory filesystem replace it.
+var runGo = func(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	var output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&output, utils.LogWriter())
+	cmd.Stderr = cmd.Stdout
+	err := cmd.Run()
+	return output.Bytes(), err
+}
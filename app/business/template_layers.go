@@ -0,0 +1,87 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/domain/ports/in"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+var EinarTemplateLayers in.EinarTemplateLayers = func(ctx context.Context) (domain.ResolvedTemplate, error) {
+	cli, err := utils.ReadEinarCli()
+	if err != nil {
+		return domain.ResolvedTemplate{}, fmt.Errorf("failed to read .einar.cli.json: %w", err)
+	}
+	return loadTemplateLayers(cli)
+}
+
+// EinarCachedTemplateLayers resolves the template layers of cli like EinarTemplateLayers,
+// without fetching anything: layers missing from the template cache are reported as
+// domain.ErrorTemplateNotFound.
+var EinarCachedTemplateLayers in.EinarCachedTemplateLayers = func(ctx context.Context, cli domain.EinarCli) (domain.ResolvedTemplate, error) {
+	return readTemplateLayers(cli, false)
+}
+
+// loadTemplateLayers reads every template layer of cli from the template cache, cloning
+// the layers that are not cached yet, and resolves them along with the project overrides.
+func loadTemplateLayers(cli domain.EinarCli) (domain.ResolvedTemplate, error) {
+	return readTemplateLayers(cli, true)
+}
+
+func readTemplateLayers(cli domain.EinarCli, fetch bool) (domain.ResolvedTemplate, error) {
+	var layers []domain.TemplateLayer
+	var warnings []string
+	for _, template := range cli.TemplateLayers() {
+		if template.IsMovingRef() {
+			warnings = append(warnings, fmt.Sprintf("template %s is pinned to branch %s at commit %s, the branch may have moved since",
+				template.URL, template.Ref, template.Commit))
+			utils.Warn(warnings[len(warnings)-1])
+		}
+
+		templateFolderPath, err := utils.TemplateFolderPath(template)
+		if err != nil {
+			return domain.ResolvedTemplate{}, err
+		}
+
+		readTemplate := utils.ReadCachedEinarTemplateFromBinaryPath
+		if fetch {
+			readTemplate = utils.ReadEinarTemplateFromBinaryPath
+		}
+		if !utils.IsTemplateCached(template) {
+			if !fetch {
+				return domain.ResolvedTemplate{}, domain.Errorf(domain.ErrorTemplateNotFound, "template %s is not cached", template)
+			}
+			if _, err := utils.FetchTemplate(template, "no-auth"); err != nil {
+				return domain.ResolvedTemplate{}, domain.Errorf(domain.ErrorTemplateNotFound, "error fetching template %s: %w", template, err)
+			}
+		}
+		einarTemplate, err := readTemplate(templateFolderPath)
+		if err != nil {
+			return domain.ResolvedTemplate{}, domain.Errorf(domain.ErrorTemplateNotFound, "error reading template %s: %w", template, err)
+		}
+
+		layers = append(layers, domain.TemplateLayer{
+			Template:      template,
+			Path:          templateFolderPath,
+			EinarTemplate: einarTemplate,
+		})
+	}
+	resolved, err := domain.ResolveTemplateLayers(layers)
+	if err != nil {
+		return resolved, err
+	}
+	resolved.Warnings = warnings
+	overrides, err := utils.ListFiles(filepath.FromSlash(domain.OverridesDir))
+	if err != nil && !os.IsNotExist(err) {
+		return resolved, fmt.Errorf("error reading %s: %w", domain.OverridesDir, err)
+	}
+	resolved.Overrides = make(map[string]bool, len(overrides))
+	for _, override := range overrides {
+		resolved.Overrides[override] = true
+	}
+	return resolved, nil
+}
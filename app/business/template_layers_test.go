@@ -0,0 +1,44 @@
+package business
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func TestEinarCachedTemplateLayers(t *testing.T) {
+	useMemFileSystem(t, map[string]string{
+		"/templates/api/.einar.template.json": `{"installation_commands": [{"name": "echo-server"}]}`,
+		"/templates/child/.einar.template.json": `{"extends": {"url": "https://github.com/acme/not-cached", "tag": "v1.0.0"},
+			"installation_commands": [{"name": "redis"}]}`,
+	})
+	ctx := context.Background()
+
+	resolved, err := EinarCachedTemplateLayers(ctx, domain.EinarCli{Template: domain.Template{URL: "file:///templates/api"}})
+	if _, ok := resolved.Installation("echo-server"); err != nil || !ok {
+		t.Errorf("EinarCachedTemplateLayers() = %v, %v, want the local template resolved", resolved.Installations, err)
+	}
+
+	notCached := domain.Template{URL: "https://github.com/acme/not-cached", Tag: "v1.0.0"}
+	for name, template := range map[string]domain.Template{
+		"template not cached":        notCached,
+		"parent template not cached": {URL: "file:///templates/child"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := EinarCachedTemplateLayers(ctx, domain.EinarCli{Template: template})
+			if domain.ErrorCodeOf(err) != domain.ErrorTemplateNotFound {
+				t.Errorf("EinarCachedTemplateLayers() error = %v, want %s", err, domain.ErrorTemplateNotFound)
+			}
+			templateFolderPath, err := utils.TemplateFolderPath(notCached)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(templateFolderPath); !os.IsNotExist(err) {
+				t.Errorf("EinarCachedTemplateLayers() fetched %s", notCached)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+// ArchiveManifestFile is the manifest found at the root of template archives and bundles.
+const ArchiveManifestFile = ".einar.archive.json"
+
+// ArchiveManifest describes a template archive, holding a single Tag, or a bundle of the
+// cached Tags of the template published at URL.
+type ArchiveManifest struct {
+	URL  string   `json:"url,omitempty"`
+	Tag  string   `json:"tag,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+}
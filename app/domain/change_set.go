@@ -0,0 +1,10 @@
+package domain
+
+// ChangeSet describes what an einar command changed in the project.
+type ChangeSet struct {
+	Files         []string       `json:"files,omitempty"`
+	Imports       []string       `json:"imports,omitempty"`
+	Installations []Installation `json:"installations,omitempty"`
+	Components    []Component    `json:"components,omitempty"`
+	Warnings      []string       `json:"warnings,omitempty"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+// ConfigMigration describes the upgrade of a .einar.cli.json file between schema versions.
+type ConfigMigration struct {
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Backup string `json:"backup,omitempty"`
+}
+
+// Migrated reports whether the file had to be upgraded.
+func (m ConfigMigration) Migrated() bool {
+	return m.From != m.To
+}
@@ -0,0 +1,32 @@
+package domain
+
+const (
+	CheckMissingComponentFile = "missing-component-file"
+	CheckStaleImport          = "stale-import"
+	CheckMissingLibrary       = "missing-library"
+	CheckProjectMismatch      = "project-mismatch"
+	CheckMissingTemplateCache = "missing-template-cache"
+)
+
+type DoctorReport struct {
+	Issues []DoctorIssue `json:"issues"`
+}
+
+type DoctorIssue struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+	Fixed   bool   `json:"fixed"`
+}
+
+func (r *DoctorReport) Add(check, message string, fixed bool) {
+	r.Issues = append(r.Issues, DoctorIssue{Check: check, Message: message, Fixed: fixed})
+}
+
+func (r DoctorReport) IsHealthy() bool {
+	for _, issue := range r.Issues {
+		if !issue.Fixed {
+			return false
+		}
+	}
+	return true
+}
@@ -1,15 +1,50 @@
 package domain
 
+import "strings"
+
+// CurrentSchemaVersion is the schema version of the .einar.cli.json files this einar
+// writes. Files with an older version are migrated when read.
+const CurrentSchemaVersion = 1
+
 type EinarCli struct {
+	SchemaVersion int            `json:"schema_version"`
 	Project       string         `json:"project"`
 	Template      Template       `json:"template"`
+	Templates     []Template     `json:"templates,omitempty"`
 	Installations []Installation `json:"installations"`
 	Components    []Component    `json:"components"`
 }
 
 type Template struct {
-	Tag string `json:"tag"`
-	URL string `json:"url"`
+	Tag    string `json:"tag"`
+	URL    string `json:"url"`
+	Ref    string `json:"ref,omitempty"`
+	Commit string `json:"commit,omitempty"`
+}
+
+func (t Template) String() string {
+	if t.Ref != "" {
+		return t.URL + "@" + t.Ref
+	}
+	if t.Tag == "" {
+		return t.URL
+	}
+	return t.URL + "@" + t.Tag
+}
+
+// CacheFolder returns the folder of the template cache holding t: the pinned commit for
+// templates pinned to a ref and the tag otherwise.
+func (t Template) CacheFolder() string {
+	if t.Commit != "" {
+		return t.Commit
+	}
+	return t.Tag
+}
+
+// IsMovingRef reports whether t is pinned to a ref, such as a branch, that is not the
+// commit itself and may point elsewhere after the project was pinned.
+func (t Template) IsMovingRef() bool {
+	return t.Ref != "" && !strings.HasPrefix(t.Commit, t.Ref)
 }
 
 type Installation struct {
@@ -31,3 +66,28 @@ func (c EinarCli) IsInstalled(component string) bool {
 	}
 	return false
 }
+
+// HasDependency reports whether one of dependsOn, the dependencies of a component command,
+// is installed, by name or by unique. An empty dependency means the command needs none.
+func (c EinarCli) HasDependency(dependsOn []string) bool {
+	for _, dependency := range dependsOn {
+		if dependency == "" {
+			return true
+		}
+		for _, installation := range c.Installations {
+			if dependency == installation.Name || dependency == installation.Unique {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TemplateLayers returns the templates of the project ordered from the base layer to the
+// layer with the highest precedence. Projects without a templates list have a single layer.
+func (c EinarCli) TemplateLayers() []Template {
+	if len(c.Templates) > 0 {
+		return c.Templates
+	}
+	return []Template{c.Template}
+}
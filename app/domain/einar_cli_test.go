@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestEinarCliHasDependency(t *testing.T) {
+	cli := EinarCli{Installations: []Installation{{Name: "echo-server"}, {Name: "pubsub", Unique: "orders"}}}
+	tests := []struct {
+		name      string
+		dependsOn []string
+		want      bool
+	}{
+		{"no dependencies", []string{""}, true},
+		{"installed by name", []string{"echo-server"}, true},
+		{"installed by unique", []string{"orders"}, true},
+		{"one of several", []string{"gin-server", "echo-server"}, true},
+		{"missing", []string{"gin-server"}, false},
+		{"empty list", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cli.HasDependency(tt.dependsOn); got != tt.want {
+				t.Errorf("HasDependency(%v) = %v, want %v", tt.dependsOn, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,32 @@
 package domain
 
 type EinarTemplate struct {
+	Extends              *Extends              `json:"extends,omitempty"`
 	BaseTemplate         BaseTemplate          `json:"base_template"`
 	InstallationsBase    []InstallationsBase   `json:"installations_base"`
 	InstallationCommands []InstallationCommand `json:"installation_commands"`
 	ComponentCommands    []ComponentCommands   `json:"component_commands"`
+	// ParentDirs holds the folders of the templates extended through Extends, relative to
+	// the template folder and nearest first. Inherited source paths start with one of them.
+	ParentDirs []string `json:"-"`
+}
+
+// Extends makes a template inherit the installation commands, component commands,
+// base template and base installations of the template published at URL and Tag.
+// Entries declared by the child replace the inherited ones with the same name and
+// Remove drops inherited entries by name.
+type Extends struct {
+	URL    string   `json:"url"`
+	Tag    string   `json:"tag"`
+	Remove Removals `json:"remove"`
+}
+
+type Removals struct {
+	InstallationCommands []string `json:"installation_commands"`
+	ComponentCommands    []string `json:"component_commands"`
+	InstallationsBase    []string `json:"installations_base"`
+	Folders              []string `json:"folders"`
+	Files                []string `json:"files"`
 }
 
 type BaseTemplate struct {
@@ -47,6 +69,7 @@ type InstallationCommand struct {
 	Command        string               `json:"command"`
 	Libraries      []string             `json:"libraries"`
 	DependsOn      []string             `json:"depends_on"`
+	Injections     []Injection          `json:"injections"`
 }
 
 type InstallationsBase struct {
@@ -59,6 +82,7 @@ type ComponentCommands struct {
 	Name           string          `json:"name"`
 	ComponentFiles []ComponentFile `json:"files"`
 	DependsOn      []string        `json:"depends_on"`
+	Injections     []Injection     `json:"injections"`
 }
 
 type ComponentFile struct {
@@ -89,3 +113,15 @@ type LiteralReplacement struct {
 	Target      string `json:"target"`
 	Replacement string `json:"replacement"`
 }
+
+// Injection inserts a snippet into an existing project file at a `einar:inject <marker>` comment.
+// Key identifies the inserted block so it is written once and can be removed later.
+type Injection struct {
+	DestinationFile     string               `json:"destination_file"`
+	Marker              string               `json:"marker"`
+	Key                 string               `json:"key"`
+	Snippet             string               `json:"snippet"`
+	SourceFile          string               `json:"source_file"`
+	ReplaceHolders      []ReplaceHolder      `json:"replace_holders"`
+	LiteralReplacements []LiteralReplacement `json:"literal_replacements"`
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// ErrorCode classifies the failures einar reports, both in JSON output and through the
+// process exit code.
+type ErrorCode string
+
+const (
+	ErrorNotInitialized    ErrorCode = "not-initialized"
+	ErrorTemplateNotFound  ErrorCode = "template-not-found"
+	ErrorKindUnknown       ErrorCode = "kind-unknown"
+	ErrorDependencyMissing ErrorCode = "dependency-missing"
+	ErrorAlreadyExists     ErrorCode = "already-exists"
+	ErrorIO                ErrorCode = "io"
+	// ErrorUnhealthy is reported by einar doctor when issues are left unfixed.
+	ErrorUnhealthy ErrorCode = "unhealthy"
+	// ErrorUnknown is used for the failures that fit none of the codes above.
+	ErrorUnknown ErrorCode = "error"
+)
+
+var exitCodes = map[ErrorCode]int{
+	ErrorUnknown:           1,
+	ErrorNotInitialized:    3,
+	ErrorTemplateNotFound:  4,
+	ErrorKindUnknown:       5,
+	ErrorDependencyMissing: 6,
+	ErrorAlreadyExists:     7,
+	ErrorIO:                8,
+	ErrorUnhealthy:         9,
+}
+
+// ExitCode is the process exit code of the failures classified as c.
+func (c ErrorCode) ExitCode() int {
+	if code, ok := exitCodes[c]; ok {
+		return code
+	}
+	return exitCodes[ErrorUnknown]
+}
+
+// Error is an error classified with an ErrorCode.
+type Error struct {
+	Code ErrorCode
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf formats an error like fmt.Errorf and classifies it as code.
+func Errorf(code ErrorCode, format string, args ...interface{}) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// ErrorCodeOf returns the code of the outermost Error wrapped by err. Unclassified
+// filesystem errors are reported as ErrorIO and anything else as ErrorUnknown.
+func ErrorCodeOf(err error) ErrorCode {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Code
+	}
+	var pathErr *fs.PathError
+	var linkErr *os.LinkError
+	if errors.As(err, &pathErr) || errors.As(err, &linkErr) {
+		return ErrorIO
+	}
+	return ErrorUnknown
+}
+
+// ExitCode returns the process exit code for err, 0 when it is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return ErrorCodeOf(err).ExitCode()
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	_, pathErr := os.Open("does-not-exist")
+	tests := []struct {
+		name     string
+		err      error
+		code     ErrorCode
+		exitCode int
+	}{
+		{"nil", nil, ErrorUnknown, 0},
+		{"unclassified", errors.New("boom"), ErrorUnknown, 1},
+		{"classified", Errorf(ErrorKindUnknown, "view command not found"), ErrorKindUnknown, 5},
+		{"wrapped", fmt.Errorf("for project demo: %w", Errorf(ErrorAlreadyExists, "component already exists")), ErrorAlreadyExists, 7},
+		{"outermost wins", Errorf(ErrorTemplateNotFound, "error reading template: %w", pathErr), ErrorTemplateNotFound, 4},
+		{"filesystem", fmt.Errorf("error copying file: %w", pathErr), ErrorIO, 8},
+		{"unhealthy", Errorf(ErrorUnhealthy, "issues left unfixed: %d", 2), ErrorUnhealthy, 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != nil {
+				if got := ErrorCodeOf(tt.err); got != tt.code {
+					t.Errorf("ErrorCodeOf() = %v, want %v", got, tt.code)
+				}
+			}
+			if got := ExitCode(tt.err); got != tt.exitCode {
+				t.Errorf("ExitCode() = %v, want %v", got, tt.exitCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "sort"
+
+// ManifestFile is the project file recording every file einar generated.
+const ManifestFile = ".einar/manifest.json"
+
+const (
+	FilePristine = "pristine"
+	FileModified = "modified"
+	FileDeleted  = "deleted"
+)
+
+type Manifest struct {
+	Files []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry records a generated file: its slash separated path relative to the
+// project, the init, installation or component that produced it, the tag of the template
+// it came from and the SHA-256 of the content einar wrote.
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	Origin string `json:"origin"`
+	Tag    string `json:"tag"`
+	SHA256 string `json:"sha256"`
+}
+
+type FileStatus struct {
+	Path   string `json:"path"`
+	Origin string `json:"origin"`
+	Status string `json:"status"`
+}
+
+// Entry returns the entry recorded for path.
+func (m Manifest) Entry(path string) (ManifestEntry, bool) {
+	for _, entry := range m.Files {
+		if entry.Path == path {
+			return entry, true
+		}
+	}
+	return ManifestEntry{}, false
+}
+
+// Record adds entry to the manifest, replacing the entry of the same path.
+func (m *Manifest) Record(entry ManifestEntry) {
+	for i := range m.Files {
+		if m.Files[i].Path == entry.Path {
+			m.Files[i] = entry
+			return
+		}
+	}
+	m.Files = append(m.Files, entry)
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+}
@@ -0,0 +1,14 @@
+package domain
+
+// PluginPrefix starts the names of the executables einar runs as plugin commands, like
+// einar-lint for einar lint.
+const PluginPrefix = "einar-"
+
+// Plugin is an executable found on PATH that adds a command to einar.
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// ShadowedBy is set when the plugin never runs: it names the built-in command, or the
+	// path of the plugin found earlier on PATH, that takes its name.
+	ShadowedBy string `json:"shadowed_by,omitempty"`
+}
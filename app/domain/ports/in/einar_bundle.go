@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarBundleExport func(ctx context.Context, templateURL, bundlePath string) (domain.ArchiveManifest, error)
+
+type EinarBundleImport func(ctx context.Context, bundlePath string) (domain.ArchiveManifest, error)
@@ -0,0 +1,9 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarDoctor func(ctx context.Context, fix bool) (domain.DoctorReport, error)
@@ -0,0 +1,9 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarExtractTemplate func(ctx context.Context, outputDir string) (domain.EinarTemplate, error)
@@ -1,5 +1,9 @@
 package in
 
-import "context"
+import (
+	"context"
 
-type EinarGenerate func(ctx context.Context, project string, componentKind string, componentName string) error
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarGenerate func(ctx context.Context, project string, componentKind string, componentName string) (domain.ChangeSet, error)
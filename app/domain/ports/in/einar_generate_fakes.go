@@ -0,0 +1,5 @@
+package in
+
+import "context"
+
+type EinarGenerateFakes func(ctx context.Context, project string) error
@@ -0,0 +1,5 @@
+package in
+
+import "context"
+
+type EinarImplement func(ctx context.Context, project, portFile, portName, kind string) error
@@ -1,5 +1,9 @@
 package in
 
-import "context"
+import (
+	"context"
 
-type EinarInit func(ctx context.Context, templateFilePath string, project string) error
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarInit func(ctx context.Context, templateFilePath string, project string) (domain.ChangeSet, error)
@@ -1,5 +1,9 @@
 package in
 
-import "context"
+import (
+	"context"
 
-type EinarInstall func(ctx context.Context, project, commandName string) error
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarInstall func(ctx context.Context, project, commandName string) (domain.ChangeSet, error)
@@ -0,0 +1,9 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarMigrateConfig func(ctx context.Context) (domain.ConfigMigration, error)
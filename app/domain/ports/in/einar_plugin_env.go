@@ -0,0 +1,5 @@
+package in
+
+import "context"
+
+type EinarPluginEnv func(ctx context.Context, dir string) ([]string, error)
@@ -0,0 +1,9 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarStatus func(ctx context.Context) ([]domain.FileStatus, error)
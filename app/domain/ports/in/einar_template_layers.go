@@ -0,0 +1,11 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarTemplateLayers func(ctx context.Context) (domain.ResolvedTemplate, error)
+
+type EinarCachedTemplateLayers func(ctx context.Context, cli domain.EinarCli) (domain.ResolvedTemplate, error)
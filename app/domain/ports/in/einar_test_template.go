@@ -0,0 +1,9 @@
+package in
+
+import (
+	"context"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+type EinarTestTemplate func(ctx context.Context, templateDir string) (domain.TemplateTestReport, error)
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OverridesDir is the project folder holding files that replace the template file with
+// the same source path.
+const OverridesDir = ".einar/overrides"
+
+// TemplateLayer is one of the templates of a project along with its cached folder.
+type TemplateLayer struct {
+	Template      Template
+	Path          string
+	EinarTemplate EinarTemplate
+}
+
+// ResolvedInstallation is an installation command and the index of the layer that provides it.
+// Shadowed holds the lower layers that also declare it.
+type ResolvedInstallation struct {
+	Command  InstallationCommand
+	Layer    int
+	Shadowed []int
+}
+
+// ResolvedComponent groups the variants of a component kind from the layer that provides it.
+// Shadowed holds the lower layers that also declare the kind.
+type ResolvedComponent struct {
+	Kind     string
+	Commands []ComponentCommands
+	Layer    int
+	Shadowed []int
+}
+
+type ResolvedTemplate struct {
+	Layers        []TemplateLayer
+	Installations []ResolvedInstallation
+	Components    []ResolvedComponent
+	// Overrides holds the slash separated source paths found in the overrides folder.
+	Overrides map[string]bool
+	Warnings  []string
+}
+
+// ResolveTemplateLayers merges the layers of a project. Entries of a later layer replace
+// the entries with the same installation name or component kind of the layers before it.
+// A layer declaring the same installation twice is rejected, since neither can be chosen.
+func ResolveTemplateLayers(layers []TemplateLayer) (ResolvedTemplate, error) {
+	resolved := ResolvedTemplate{Layers: layers}
+	installations := make(map[string]int)
+	components := make(map[string]int)
+
+	for layer, templateLayer := range layers {
+		seen := make(map[string]bool)
+		for _, command := range templateLayer.EinarTemplate.InstallationCommands {
+			if seen[command.Name] {
+				return ResolvedTemplate{}, fmt.Errorf("template %s declares installation %s more than once", templateLayer.Template, command.Name)
+			}
+			seen[command.Name] = true
+			if i, ok := installations[command.Name]; ok {
+				previous := resolved.Installations[i]
+				resolved.Installations[i] = ResolvedInstallation{
+					Command:  command,
+					Layer:    layer,
+					Shadowed: append(previous.Shadowed, previous.Layer),
+				}
+				continue
+			}
+			installations[command.Name] = len(resolved.Installations)
+			resolved.Installations = append(resolved.Installations, ResolvedInstallation{Command: command, Layer: layer})
+		}
+
+		kinds := make(map[string][]ComponentCommands)
+		var order []string
+		for _, command := range templateLayer.EinarTemplate.ComponentCommands {
+			if _, ok := kinds[command.Kind]; !ok {
+				order = append(order, command.Kind)
+			}
+			kinds[command.Kind] = append(kinds[command.Kind], command)
+		}
+		for _, kind := range order {
+			if i, ok := components[kind]; ok {
+				previous := resolved.Components[i]
+				resolved.Components[i] = ResolvedComponent{
+					Kind:     kind,
+					Commands: kinds[kind],
+					Layer:    layer,
+					Shadowed: append(previous.Shadowed, previous.Layer),
+				}
+				continue
+			}
+			components[kind] = len(resolved.Components)
+			resolved.Components = append(resolved.Components, ResolvedComponent{Kind: kind, Commands: kinds[kind], Layer: layer})
+		}
+	}
+	return resolved, nil
+}
+
+func (r ResolvedTemplate) Installation(name string) (ResolvedInstallation, bool) {
+	for _, installation := range r.Installations {
+		if installation.Command.Name == name {
+			return installation, true
+		}
+	}
+	return ResolvedInstallation{}, false
+}
+
+func (r ResolvedTemplate) Component(kind string) (ResolvedComponent, bool) {
+	for _, component := range r.Components {
+		if component.Kind == kind {
+			return component, true
+		}
+	}
+	return ResolvedComponent{}, false
+}
+
+// LayerPath returns the cached folder of the layer at index layer.
+func (r ResolvedTemplate) LayerPath(layer int) string {
+	return r.Layers[layer].Path
+}
+
+// SourcePath returns the file to read for sourceFile of the layer at index layer: the
+// project override when there is one and the cached template file otherwise.
+func (r ResolvedTemplate) SourcePath(layer int, sourceFile string) string {
+	if r.IsOverridden(layer, sourceFile) {
+		return filepath.Join(filepath.FromSlash(OverridesDir), filepath.FromSlash(r.OverrideKey(layer, sourceFile)))
+	}
+	return filepath.Join(r.LayerPath(layer), sourceFile)
+}
+
+func (r ResolvedTemplate) IsOverridden(layer int, sourceFile string) bool {
+	return r.Overrides[r.OverrideKey(layer, sourceFile)]
+}
+
+// OverrideKey returns the slash separated path overriding sourceFile of the layer at index
+// layer is found at in the overrides folder: its path in the template declaring it, without
+// the parent folder prefixed to the paths inherited through extends.
+func (r ResolvedTemplate) OverrideKey(layer int, sourceFile string) string {
+	key := filepath.ToSlash(filepath.Clean(sourceFile))
+	parentPrefix := ""
+	for _, dir := range r.Layers[layer].EinarTemplate.ParentDirs {
+		prefix := filepath.ToSlash(filepath.Clean(dir)) + "/"
+		if strings.HasPrefix(key, prefix) && len(prefix) > len(parentPrefix) {
+			parentPrefix = prefix
+		}
+	}
+	return strings.TrimPrefix(key, parentPrefix)
+}
+
+// OverridesIn returns the overridden source paths below the template folder sourceDir of
+// the layer at index layer, as override keys.
+func (r ResolvedTemplate) OverridesIn(layer int, sourceDir string) []string {
+	prefix := r.OverrideKey(layer, sourceDir) + "/"
+	var overrides []string
+	for sourceFile := range r.Overrides {
+		if strings.HasPrefix(sourceFile, prefix) {
+			overrides = append(overrides, sourceFile)
+		}
+	}
+	sort.Strings(overrides)
+	return overrides
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveTemplateLayers(t *testing.T) {
+	base := TemplateLayer{
+		Template: Template{URL: "https://github.com/platform/base", Tag: "v1.0.0"},
+		EinarTemplate: EinarTemplate{
+			InstallationCommands: []InstallationCommand{{Name: "echo-server"}, {Name: "pubsub"}},
+			ComponentCommands: []ComponentCommands{
+				{Kind: "get-controller", DependsOn: []string{"echo-server"}},
+				{Kind: "subscription", DependsOn: []string{"pubsub"}},
+			},
+		},
+	}
+	product := TemplateLayer{
+		Template: Template{URL: "https://github.com/product/layer", Tag: "v0.1.0"},
+		EinarTemplate: EinarTemplate{
+			InstallationCommands: []InstallationCommand{{Name: "pubsub", Unique: "product"}},
+			ComponentCommands: []ComponentCommands{
+				{Kind: "get-controller", DependsOn: []string{"echo-server"}},
+				{Kind: "get-controller", DependsOn: []string{"gin-server"}},
+				{Kind: "view"},
+			},
+		},
+	}
+
+	resolved, err := ResolveTemplateLayers([]TemplateLayer{base, product})
+	if err != nil {
+		t.Fatalf("ResolveTemplateLayers() error = %v", err)
+	}
+
+	installation, ok := resolved.Installation("pubsub")
+	if !ok || installation.Layer != 1 || installation.Command.Unique != "product" {
+		t.Errorf("expected pubsub from the product layer, got %+v", installation)
+	}
+	if len(installation.Shadowed) != 1 || installation.Shadowed[0] != 0 {
+		t.Errorf("expected pubsub to shadow the base layer, got %v", installation.Shadowed)
+	}
+
+	component, ok := resolved.Component("get-controller")
+	if !ok || component.Layer != 1 || len(component.Commands) != 2 {
+		t.Errorf("expected both get-controller variants from the product layer, got %+v", component)
+	}
+
+	component, ok = resolved.Component("subscription")
+	if !ok || component.Layer != 0 || len(component.Shadowed) != 0 {
+		t.Errorf("expected subscription from the base layer, got %+v", component)
+	}
+
+	if _, ok := resolved.Component("view"); !ok {
+		t.Errorf("expected view to be resolved")
+	}
+}
+
+func TestResolveTemplateLayersDuplicateInstallation(t *testing.T) {
+	layer := TemplateLayer{
+		Template: Template{URL: "https://github.com/platform/base", Tag: "v1.0.0"},
+		EinarTemplate: EinarTemplate{
+			InstallationCommands: []InstallationCommand{{Name: "pubsub"}, {Name: "pubsub", Unique: "second"}},
+		},
+	}
+	if _, err := ResolveTemplateLayers([]TemplateLayer{layer}); err == nil {
+		t.Error("ResolveTemplateLayers() accepted a layer declaring pubsub twice")
+	}
+}
+
+func TestResolvedTemplateSourcePath(t *testing.T) {
+	resolved := ResolvedTemplate{
+		Layers:    []TemplateLayer{{Path: filepath.Join("cache", "tpl", "v1.0.0")}},
+		Overrides: map[string]bool{"app/adapter/in/controller/get.go": true, "app/shared/config/config.go": true},
+	}
+
+	if got, want := resolved.SourcePath(0, "app/adapter/in/controller/get.go"), filepath.Join(".einar", "overrides", "app", "adapter", "in", "controller", "get.go"); got != want {
+		t.Errorf("SourcePath() of an overridden file = %s, want %s", got, want)
+	}
+	if got, want := resolved.SourcePath(0, "app/adapter/in/controller/post.go"), filepath.Join("cache", "tpl", "v1.0.0", "app", "adapter", "in", "controller", "post.go"); got != want {
+		t.Errorf("SourcePath() of a template file = %s, want %s", got, want)
+	}
+	if got := resolved.OverridesIn(0, "app/shared"); len(got) != 1 || got[0] != "app/shared/config/config.go" {
+		t.Errorf("OverridesIn() = %v, want [app/shared/config/config.go]", got)
+	}
+}
+
+func TestResolvedTemplateSourcePathInherited(t *testing.T) {
+	parentDir := filepath.Join("..", "..", "base", "v1.0.0")
+	grandparentDir := filepath.Join(parentDir, "..", "..", "core", "v2.0.0")
+	resolved := ResolvedTemplate{
+		Layers: []TemplateLayer{{
+			Path:          filepath.Join("cache", "child", "v1.0.0"),
+			EinarTemplate: EinarTemplate{ParentDirs: []string{parentDir, grandparentDir}},
+		}},
+		Overrides: map[string]bool{"app/adapter/in/controller/get.go": true, "app/shared/config/config.go": true},
+	}
+	override := filepath.Join(".einar", "overrides", "app", "adapter", "in", "controller", "get.go")
+
+	tests := []struct {
+		name       string
+		sourceFile string
+		want       string
+	}{
+		{"inherited from the parent", filepath.Join(parentDir, "app/adapter/in/controller/get.go"), override},
+		{"inherited from the grandparent", filepath.Join(grandparentDir, "app/adapter/in/controller/get.go"), override},
+		{"declared by the layer", "app/adapter/in/controller/get.go", override},
+		{"not overridden", filepath.Join(parentDir, "app/adapter/in/controller/post.go"),
+			filepath.Join("cache", "child", "v1.0.0", parentDir, "app/adapter/in/controller/post.go")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolved.SourcePath(0, tt.sourceFile); got != tt.want {
+				t.Errorf("SourcePath() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if got := resolved.OverridesIn(0, filepath.Join(parentDir, "app/shared")); len(got) != 1 || got[0] != "app/shared/config/config.go" {
+		t.Errorf("OverridesIn() of an inherited folder = %v, want [app/shared/config/config.go]", got)
+	}
+}
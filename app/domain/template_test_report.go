@@ -0,0 +1,30 @@
+package domain
+
+// TemplateTestScenario is a scratch project created from a template with a set of
+// installations and the component kinds generated on top of them.
+type TemplateTestScenario struct {
+	Installations []string              `json:"installations"`
+	Kinds         []string              `json:"kinds"`
+	Failures      []TemplateTestFailure `json:"failures"`
+}
+
+// TemplateTestFailure is a step of a scenario that failed. Step is one of init, install,
+// generate, build or vet and Target is the installation or kind involved, if any.
+type TemplateTestFailure struct {
+	Step   string `json:"step"`
+	Target string `json:"target"`
+	Output string `json:"output"`
+}
+
+type TemplateTestReport struct {
+	Scenarios []TemplateTestScenario `json:"scenarios"`
+}
+
+func (r TemplateTestReport) Passed() bool {
+	for _, scenario := range r.Scenarios {
+		if len(scenario.Failures) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -1,57 +1,55 @@
 package utils
 
 import (
-	"bufio"
-	"fmt"
-	"os"
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strconv"
 	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
 )
 
+// AddImportStatement adds a blank import of importPath to the Go file at filePath.
+// Files with a single-line import or no imports at all are supported and the
+// import is skipped when the exact path is already imported.
 func AddImportStatement(filePath, importPath string) error {
 	importPath = strings.ReplaceAll(importPath, "\\", "/")
-	file, err := os.Open(filePath)
+	src, err := FS.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
-
-	var lines []string
-	var inImportBlock bool
-	scanner := bufio.NewScanner(file)
-	alreadyImported := false
-	for scanner.Scan() {
-		line := scanner.Text()
-		if inImportBlock && strings.TrimSpace(line) == ")" {
-			inImportBlock = false
-			// add the import just before the closing parenthesis of the import block
-			if !alreadyImported {
-				lines = append(lines, fmt.Sprintf("\t_ \"%s\"", importPath))
-			}
-		}
-		// check if the import already exists
-		if inImportBlock && strings.Contains(line, importPath) {
-			alreadyImported = true
-		}
-		lines = append(lines, line)
-		if strings.Contains(line, "import (") {
-			inImportBlock = true
-		}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return err
 	}
 
-	if scanner.Err() != nil {
-		return scanner.Err()
+	if hasImport(file, importPath) {
+		return nil
 	}
 
-	// write the updated lines back to the file
-	file, err = os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+	astutil.AddNamedImport(fset, file, "_", importPath)
+	return writeGoFile(filePath, fset, file)
+}
 
-	for _, line := range lines {
-		fmt.Fprintln(file, line)
+func hasImport(file *ast.File, importPath string) bool {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err == nil && path == importPath {
+			return true
+		}
 	}
+	return false
+}
 
-	return nil
+func writeGoFile(filePath string, fset *token.FileSet, file *ast.File) error {
+	ast.SortImports(fset, file)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return err
+	}
+	return FS.WriteFile(filePath, buf.Bytes(), 0644)
 }
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddImportStatement(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		importPath string
+		want       string
+	}{
+		{
+			name: "import block",
+			source: `package main
+
+import (
+	"fmt"
+)
+
+func main() { fmt.Println() }
+`,
+			importPath: "my-project/app/adapter/in/controller",
+			want: `package main
+
+import (
+	"fmt"
+	_ "my-project/app/adapter/in/controller"
+)
+
+func main() { fmt.Println() }
+`,
+		},
+		{
+			name: "single line import",
+			source: `package main
+
+import "my-project/app/shared/archetype"
+
+func main() { archetype.Setup() }
+`,
+			importPath: "my-project/app/adapter/in/controller",
+			want: `package main
+
+import (
+	_ "my-project/app/adapter/in/controller"
+	"my-project/app/shared/archetype"
+)
+
+func main() { archetype.Setup() }
+`,
+		},
+		{
+			name: "no imports",
+			source: `package main
+
+func main() {}
+`,
+			importPath: "my-project/app/adapter/in/controller",
+			want: `package main
+
+import _ "my-project/app/adapter/in/controller"
+
+func main() {}
+`,
+		},
+		{
+			name: "prefix of an existing import is not a duplicate",
+			source: `package main
+
+import (
+	_ "foo/barbaz"
+)
+`,
+			importPath: "foo/bar",
+			want: `package main
+
+import (
+	_ "foo/bar"
+	_ "foo/barbaz"
+)
+`,
+		},
+		{
+			name: "already imported",
+			source: `package main
+
+import (
+	_ "foo/bar"
+)
+`,
+			importPath: "foo/bar",
+			want: `package main
+
+import (
+	_ "foo/bar"
+)
+`,
+		},
+		{
+			name: "windows separators",
+			source: `package main
+
+import (
+	_ "foo/bar"
+)
+`,
+			importPath: `foo\baz`,
+			want: `package main
+
+import (
+	_ "foo/bar"
+	_ "foo/baz"
+)
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "main.go")
+			if err := os.WriteFile(filePath, []byte(tt.source), 0644); err != nil {
+				t.Fatalf("Failed to write main.go: %v", err)
+			}
+			if err := AddImportStatement(filePath, tt.importPath); err != nil {
+				t.Fatalf("AddImportStatement() error = %v", err)
+			}
+			got, _ := os.ReadFile(filePath)
+			if string(got) != tt.want {
+				t.Errorf("AddImportStatement() got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveImportStatement(t *testing.T) {
+	source := `package main
+
+import (
+	_ "foo/bar"
+	_ "foo/barbaz"
+	"my-project/app/shared/archetype"
+)
+
+func main() { archetype.Setup() }
+`
+	want := `package main
+
+import (
+	_ "foo/barbaz"
+	"my-project/app/shared/archetype"
+)
+
+func main() { archetype.Setup() }
+`
+	filePath := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write main.go: %v", err)
+	}
+	if err := RemoveImportStatement(filePath, "foo/bar"); err != nil {
+		t.Fatalf("RemoveImportStatement() error = %v", err)
+	}
+	got, _ := os.ReadFile(filePath)
+	if string(got) != want {
+		t.Errorf("RemoveImportStatement() got\n%s\nwant\n%s", got, want)
+	}
+}
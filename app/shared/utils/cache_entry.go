@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// cacheEntryMarker is written last into every template cache entry. Entries without it
+// were left half-written by an interrupted run.
+const cacheEntryMarker = ".einar.cache"
+
+// IsCacheEntryComplete reports whether the template cache entry at entryPath was fully
+// written. Like the rest of the cache entry lifecycle, the marker, the lock and the rename
+// into place, it works on the disk and not on FS: entries are built by git and archive
+// extraction, which only write to the disk.
+func IsCacheEntryComplete(entryPath string) bool {
+	_, err := os.Stat(filepath.Join(entryPath, cacheEntryMarker))
+	return err == nil
+}
+
+// IsTemplateCached reports whether template can be read without fetching it. Local
+// templates are always available.
+func IsTemplateCached(template domain.Template) bool {
+	if strings.HasPrefix(template.URL, LocalTemplateScheme) && !IsArchive(template.URL) {
+		return true
+	}
+	templateFolderPath, err := TemplateFolderPath(template)
+	if err != nil {
+		return false
+	}
+	return IsCacheEntryComplete(templateFolderPath)
+}
+
+// InstallCacheEntry populates the template cache entry at entryPath. The entry is built by
+// populate in a temporary folder next to it and renamed into place while holding the entry
+// lock, so concurrent runs never see a partial entry. Complete entries are kept unless
+// replace is set; half-written entries and temporary folders of interrupted runs are removed.
+func InstallCacheEntry(entryPath string, replace bool, populate func(dir string) error) error {
+	parent, name := filepath.Dir(entryPath), filepath.Base(entryPath)
+	if err := os.MkdirAll(parent, os.ModePerm); err != nil {
+		return err
+	}
+
+	unlock, err := LockCacheEntry(entryPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if IsCacheEntryComplete(entryPath) && !replace {
+		return nil
+	}
+	tmpPattern := "." + name + ".tmp-"
+	if leftovers, err := filepath.Glob(filepath.Join(parent, tmpPattern+"*")); err == nil {
+		for _, leftover := range leftovers {
+			os.RemoveAll(leftover)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp(parent, tmpPattern)
+	if err != nil {
+		return fmt.Errorf("error creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := populate(tmpDir); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, cacheEntryMarker), nil, 0644); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(entryPath); err != nil {
+		return fmt.Errorf("error removing stale cache entry %s: %w", entryPath, err)
+	}
+	return os.Rename(tmpDir, entryPath)
+}
+
+// LockCacheEntry takes the advisory lock of the template cache entry at entryPath, waiting
+// for other einar processes that hold it, and returns the function releasing it.
+func LockCacheEntry(entryPath string) (func(), error) {
+	file, err := os.OpenFile(entryPath+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening lock of %s: %w", entryPath, err)
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error locking %s: %w", entryPath, err)
+	}
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestInstallCacheEntry(t *testing.T) {
+	entryPath := filepath.Join(t.TempDir(), "github.com", "x", "tpl", "v1.0.0")
+
+	// A half-written entry and the temp folder of an interrupted run.
+	if err := os.MkdirAll(entryPath, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	leftover := filepath.Join(filepath.Dir(entryPath), ".v1.0.0.tmp-123")
+	if err := os.MkdirAll(leftover, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	var populated int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := InstallCacheEntry(entryPath, false, func(dir string) error {
+				atomic.AddInt32(&populated, 1)
+				return os.WriteFile(filepath.Join(dir, ".einar.template.json"), []byte("{}"), 0644)
+			})
+			if err != nil {
+				t.Errorf("InstallCacheEntry() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if populated != 1 {
+		t.Errorf("InstallCacheEntry() populated the entry %d times, want 1", populated)
+	}
+	if !IsCacheEntryComplete(entryPath) {
+		t.Errorf("IsCacheEntryComplete() = false after install")
+	}
+	if _, err := os.Stat(filepath.Join(entryPath, ".einar.template.json")); err != nil {
+		t.Errorf("entry content missing: %v", err)
+	}
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Errorf("leftover temp folder was not removed")
+	}
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CacheTagPath joins tag to the template cache folder targetPath, rejecting tags read from
+// archives and bundles that are not a single folder name inside it.
+func CacheTagPath(targetPath, tag string) (string, error) {
+	if tag == "" || strings.ContainsAny(tag, `/\`) || strings.Contains(tag, "..") || filepath.IsAbs(tag) {
+		return "", fmt.Errorf("invalid template tag %q", tag)
+	}
+	root := filepath.Clean(targetPath)
+	tagFolderPath := filepath.Clean(filepath.Join(root, tag))
+	if !strings.HasPrefix(tagFolderPath, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("template tag %q escapes the template cache %s", tag, root)
+	}
+	return tagFolderPath, nil
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCacheTagPath(t *testing.T) {
+	targetPath := filepath.Join(t.TempDir(), "github.com", "acme", "api")
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{tag: "v1.0.0"},
+		{tag: "main"},
+		{tag: "", wantErr: true},
+		{tag: ".", wantErr: true},
+		{tag: "..", wantErr: true},
+		{tag: "../../victim", wantErr: true},
+		{tag: "v1/../../victim", wantErr: true},
+		{tag: `..\victim`, wantErr: true},
+		{tag: "/tmp/victim", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := CacheTagPath(targetPath, tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CacheTagPath(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != filepath.Join(targetPath, tt.tag) {
+				t.Errorf("CacheTagPath(%q) = %s, want %s", tt.tag, got, filepath.Join(targetPath, tt.tag))
+			}
+		})
+	}
+}
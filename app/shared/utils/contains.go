@@ -0,0 +1,11 @@
+package utils
+
+// Contains reports whether name is one of names.
+func Contains(names []string, name string) bool {
+	for _, v := range names {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,49 +1,134 @@
 package utils
 
 import (
-	"regexp"
 	"strings"
+	"unicode"
 )
 
+// Acronyms are rendered fully uppercased by the PascalCase, camelCase and Title Case conversions.
+var Acronyms = []string{"API", "DB", "GRPC", "HTML", "HTTP", "HTTPS", "ID", "JSON", "SQL", "URI", "URL", "UUID", "XML"}
+
 // ConvertStringCase converts a string to a given case.
-// Supported cases are: snake_case, PascalCase and camelCase.
+// Supported cases are: snake_case, SCREAMING_SNAKE_CASE, PascalCase, camelCase, kebab,
+// dot.case and Title Case. The case may be prefixed with "plural:" or "singular:" to
+// inflect the last word, e.g. "plural:PascalCase" turns get-customer into GetCustomers.
+// Words are split on any separator, on lower to upper case transitions and before the
+// last letter of an uppercase run, so HTTPClient, http_client and http-client are the
+// same two words. A lone s closing an uppercase run stays in it, so userIDs is user ids.
+// Slash separated segments are converted one by one.
 func ConvertStringCase(s, caseType string) string {
+	inflection := ""
+	if i := strings.Index(caseType, ":"); i >= 0 {
+		inflection, caseType = caseType[:i], caseType[i+1:]
+	}
+
+	var convert func(words []string) string
 	switch caseType {
 	case "snake_case":
-		return toSnakeCase(s)
+		convert = func(words []string) string { return strings.Join(words, "_") }
+	case "SCREAMING_SNAKE_CASE":
+		convert = func(words []string) string { return strings.ToUpper(strings.Join(words, "_")) }
 	case "PascalCase":
-		return toPascalCase(s)
+		convert = toPascalCase
 	case "camelCase":
-		return toCamelCase(s)
+		convert = toCamelCase
 	case "kebab":
-		return toKebabCase(s)
+		convert = func(words []string) string { return strings.Join(words, "-") }
+	case "dot.case":
+		convert = func(words []string) string { return strings.Join(words, ".") }
+	case "Title Case":
+		convert = toTitleCase
 	default:
 		return s
 	}
+
+	segments := strings.Split(s, "/")
+	for i, segment := range segments {
+		words := splitWords(segment)
+		if len(words) > 0 && i == len(segments)-1 {
+			switch inflection {
+			case "plural":
+				words[len(words)-1] = Pluralize(words[len(words)-1])
+			case "singular":
+				words[len(words)-1] = Singularize(words[len(words)-1])
+			}
+		}
+		segments[i] = convert(words)
+	}
+	return strings.Join(segments, "/")
+}
+
+// splitWords splits s into lowercase words. Digits stay attached to the word before them.
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if len(current) > 0 && unicode.IsUpper(r) {
+			prev := current[len(current)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// a lone s after an uppercase run makes it plural, e.g. IDs, instead of starting a word
+			if nextIsLower && runes[i+1] == 's' && (i+2 == len(runes) || !unicode.IsLower(runes[i+2])) {
+				nextIsLower = false
+			}
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
 }
 
-func toKebabCase(s string) string {
-	re := regexp.MustCompile(`([a-z0-9])([A-Z])`)
-	s = re.ReplaceAllStringFunc(s, func(match string) string {
-		return match[:1] + "-" + match[1:]
-	})
-	s = strings.ReplaceAll(s, "_", "-")
-	return strings.ToLower(s)
+func capitalize(word string) string {
+	upper := strings.ToUpper(word)
+	for _, acronym := range Acronyms {
+		if upper == acronym {
+			return upper
+		}
+		// plural acronyms, as left by the plural: inflection, e.g. IDs
+		if upper == acronym+"S" {
+			return acronym + "s"
+		}
+	}
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	return string(unicode.ToUpper(runes[0])) + string(runes[1:])
 }
 
-func toSnakeCase(s string) string {
-	return strings.ReplaceAll(strings.ToLower(s), "-", "_")
+func toPascalCase(words []string) string {
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(capitalize(word))
+	}
+	return b.String()
 }
 
-func toPascalCase(s string) string {
-	words := strings.Split(s, "-")
-	for i := 0; i < len(words); i++ {
-		words[i] = strings.Title(words[i])
+func toCamelCase(words []string) string {
+	if len(words) == 0 {
+		return ""
 	}
-	return strings.Join(words, "")
+	return words[0] + toPascalCase(words[1:])
 }
 
-func toCamelCase(s string) string {
-	s = toPascalCase(s)
-	return strings.ToLower(s[:1]) + s[1:]
+func toTitleCase(words []string) string {
+	titled := make([]string, len(words))
+	for i, word := range words {
+		titled[i] = capitalize(word)
+	}
+	return strings.Join(titled, " ")
 }
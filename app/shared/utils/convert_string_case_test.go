@@ -0,0 +1,68 @@
+package utils
+
+import "testing"
+
+func TestConvertStringCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		caseType string
+		want     string
+	}{
+		{"get_customer", "PascalCase", "GetCustomer"},
+		{"get-customer", "PascalCase", "GetCustomer"},
+		{"getCustomer", "PascalCase", "GetCustomer"},
+		{"get-customer", "camelCase", "getCustomer"},
+		{"pull_customer_created", "kebab", "pull-customer-created"},
+		{"pull-customer-created", "snake_case", "pull_customer_created"},
+		{"HTTPClient", "kebab", "http-client"},
+		{"HTTPClient", "snake_case", "http_client"},
+		{"http-client", "PascalCase", "HTTPClient"},
+		{"http-client", "camelCase", "httpClient"},
+		{"get customer id", "PascalCase", "GetCustomerID"},
+		{"mySubscription1", "kebab", "my-subscription1"},
+		{"HTTP2Server", "snake_case", "http2_server"},
+		{"projecta/mySubscription1", "kebab", "projecta/my-subscription1"},
+		{"publish-customer", "SCREAMING_SNAKE_CASE", "PUBLISH_CUSTOMER"},
+		{"publish-customer", "dot.case", "publish.customer"},
+		{"read_customer_api", "Title Case", "Read Customer API"},
+		{"get-customer", "plural:PascalCase", "GetCustomers"},
+		{"category", "plural:snake_case", "categories"},
+		{"box", "plural:kebab", "boxes"},
+		{"person", "plural:camelCase", "people"},
+		{"status", "plural:snake_case", "statuses"},
+		{"get-customers", "singular:PascalCase", "GetCustomer"},
+		{"categories", "singular:kebab", "category"},
+		{"addresses", "singular:kebab", "address"},
+		{"statuses", "singular:kebab", "status"},
+		{"buses", "singular:kebab", "bus"},
+		{"causes", "singular:kebab", "cause"},
+		{"quizzes", "singular:kebab", "quiz"},
+		{"heroes", "singular:kebab", "hero"},
+		{"shoes", "singular:kebab", "shoe"},
+		{"movies", "singular:kebab", "movie"},
+		{"indices", "singular:kebab", "index"},
+		{"userIDs", "singular:PascalCase", "UserID"},
+		{"quiz", "plural:PascalCase", "Quizzes"},
+		{"hero", "plural:kebab", "heroes"},
+		{"video", "plural:kebab", "videos"},
+		{"bus", "plural:kebab", "buses"},
+		{"already-users", "plural:PascalCase", "AlreadyUsers"},
+		{"userIDs", "plural:PascalCase", "UserIDs"},
+		{"user-id", "plural:PascalCase", "UserIDs"},
+		{"api", "plural:camelCase", "apis"},
+		{"user-api", "plural:camelCase", "userAPIs"},
+		{"userIDs", "snake_case", "user_ids"},
+		{"URLsList", "kebab", "urls-list"},
+		{"HTTPServer", "snake_case", "http_server"},
+		{"get-customer", "unknown", "get-customer"},
+		{"", "camelCase", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input+" "+tt.caseType, func(t *testing.T) {
+			if got := ConvertStringCase(tt.input, tt.caseType); got != tt.want {
+				t.Errorf("ConvertStringCase(%q, %q) = %q, want %q", tt.input, tt.caseType, got, tt.want)
+			}
+		})
+	}
+}
@@ -7,14 +7,14 @@ import (
 )
 
 func CopyDirectory(srcDir string, dstDir string, placeholders []string, values []string) error {
-	entries, err := os.ReadDir(srcDir)
+	entries, err := FS.ReadDir(srcDir)
 	if err != nil {
-		return fmt.Errorf("error reading source directory: %v", err)
+		return fmt.Errorf("error reading source directory: %w", err)
 	}
 
-	err = os.MkdirAll(dstDir, os.ModePerm)
+	err = FS.MkdirAll(dstDir, os.ModePerm)
 	if err != nil {
-		return fmt.Errorf("error creating destination directory: %v", err)
+		return fmt.Errorf("error creating destination directory: %w", err)
 	}
 
 	for _, entry := range entries {
@@ -23,7 +23,7 @@ func CopyDirectory(srcDir string, dstDir string, placeholders []string, values [
 
 		fileInfo, err := entry.Info()
 		if err != nil {
-			return fmt.Errorf("error retrieving file info: %v", err)
+			return fmt.Errorf("error retrieving file info: %w", err)
 		}
 
 		if fileInfo.IsDir() {
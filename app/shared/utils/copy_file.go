@@ -2,47 +2,36 @@ package utils
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 )
 
 func CopyFile(srcFile string, dstFile string, placeholders []string, values []string) error {
-	in, err := os.Open(srcFile)
+	content, err := FS.ReadFile(srcFile)
 	if err != nil {
-		return fmt.Errorf("error opening source file: %v", err)
+		return fmt.Errorf("error opening source file: %w", err)
 	}
-	defer in.Close()
 
 	// Create the destination directory if it doesn't exist yet
 	dstDir := filepath.Dir(dstFile)
-	if _, err := os.Stat(dstDir); os.IsNotExist(err) {
-		err = os.MkdirAll(dstDir, 0755)
+	if _, err := FS.Stat(dstDir); os.IsNotExist(err) {
+		err = FS.MkdirAll(dstDir, 0755)
 		if err != nil {
-			return fmt.Errorf("error creating directory %s: %v", dstDir, err)
+			return fmt.Errorf("error creating directory %s: %w", dstDir, err)
 		}
 	}
 
-	out, err := os.Create(dstFile)
+	err = FS.WriteFile(dstFile, content, 0666)
 	if err != nil {
-		return fmt.Errorf("error creating destination file: %v", err)
+		return fmt.Errorf("error copying file content: %w", err)
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, in)
-	if err != nil {
-		return fmt.Errorf("error copying file content: %v", err)
-	}
-
-	err = out.Sync()
-	if err != nil {
-		return fmt.Errorf("error syncing destination file: %v", err)
-	}
+	Debug("file copied", "source", srcFile, "destination", dstFile)
 
 	// Replace "${project}" placeholder in the copied file
 	err = replacePlaceholders(dstFile, placeholders, values)
 	if err != nil {
-		return fmt.Errorf("error replacing placeholder in file: %v", err)
+		return fmt.Errorf("error replacing placeholder in file: %w", err)
 	}
 
 	return nil
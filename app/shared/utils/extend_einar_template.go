@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"path/filepath"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// ExtendEinarTemplate merges child over parent. The source paths inherited from parent are
+// prefixed with parentDir, the parent folder relative to the child folder, so they keep
+// resolving into the parent cache when joined with the child template path.
+func ExtendEinarTemplate(parent, child domain.EinarTemplate, parentDir string) domain.EinarTemplate {
+	var remove domain.Removals
+	if child.Extends != nil {
+		remove = child.Extends.Remove
+	}
+	rebase := func(path string) string {
+		if path == "" {
+			return path
+		}
+		return filepath.Join(parentDir, path)
+	}
+
+	merged := domain.EinarTemplate{Extends: child.Extends, ParentDirs: []string{parentDir}}
+	for _, dir := range parent.ParentDirs {
+		merged.ParentDirs = append(merged.ParentDirs, rebase(dir))
+	}
+
+	merged.BaseTemplate.Description = parent.BaseTemplate.Description
+	if child.BaseTemplate.Description != "" {
+		merged.BaseTemplate.Description = child.BaseTemplate.Description
+	}
+
+	for _, folder := range parent.BaseTemplate.Folders {
+		if Contains(remove.Folders, folder.DestinationDir) || containsFolder(child.BaseTemplate.Folders, folder.DestinationDir) {
+			continue
+		}
+		folder.SourceDir = rebase(folder.SourceDir)
+		merged.BaseTemplate.Folders = append(merged.BaseTemplate.Folders, folder)
+	}
+	merged.BaseTemplate.Folders = append(merged.BaseTemplate.Folders, child.BaseTemplate.Folders...)
+
+	for _, file := range parent.BaseTemplate.Files {
+		if Contains(remove.Files, file.DestinationFile) || containsFile(child.BaseTemplate.Files, file.DestinationFile) {
+			continue
+		}
+		file.SourceFile = rebase(file.SourceFile)
+		merged.BaseTemplate.Files = append(merged.BaseTemplate.Files, file)
+	}
+	merged.BaseTemplate.Files = append(merged.BaseTemplate.Files, child.BaseTemplate.Files...)
+
+	for _, installation := range parent.InstallationsBase {
+		if Contains(remove.InstallationsBase, installation.Name) || containsInstallationBase(child.InstallationsBase, installation.Name) {
+			continue
+		}
+		merged.InstallationsBase = append(merged.InstallationsBase, installation)
+	}
+	merged.InstallationsBase = append(merged.InstallationsBase, child.InstallationsBase...)
+
+	for _, command := range parent.InstallationCommands {
+		if Contains(remove.InstallationCommands, command.Name) || containsInstallationCommand(child.InstallationCommands, command.Name) {
+			continue
+		}
+		command.SourceDir = rebase(command.SourceDir)
+		folders := make([]domain.InstallationFolder, len(command.Folders))
+		for i, folder := range command.Folders {
+			folder.SourceDir = rebase(folder.SourceDir)
+			folders[i] = folder
+		}
+		command.Folders = folders
+		files := make([]domain.InstallationFile, len(command.Files))
+		for i, file := range command.Files {
+			file.SourceFile = rebase(file.SourceFile)
+			file.Port.SourceFile = rebase(file.Port.SourceFile)
+			files[i] = file
+		}
+		command.Files = files
+		command.Injections = rebaseInjections(command.Injections, rebase)
+		merged.InstallationCommands = append(merged.InstallationCommands, command)
+	}
+	merged.InstallationCommands = append(merged.InstallationCommands, child.InstallationCommands...)
+
+	for _, command := range parent.ComponentCommands {
+		if Contains(remove.ComponentCommands, command.Kind) || containsComponentCommand(child.ComponentCommands, command.Kind) {
+			continue
+		}
+		files := make([]domain.ComponentFile, len(command.ComponentFiles))
+		for i, file := range command.ComponentFiles {
+			file.SourceFile = rebase(file.SourceFile)
+			file.Port.SourceFile = rebase(file.Port.SourceFile)
+			files[i] = file
+		}
+		command.ComponentFiles = files
+		command.Injections = rebaseInjections(command.Injections, rebase)
+		merged.ComponentCommands = append(merged.ComponentCommands, command)
+	}
+	merged.ComponentCommands = append(merged.ComponentCommands, child.ComponentCommands...)
+
+	return merged
+}
+
+func rebaseInjections(injections []domain.Injection, rebase func(string) string) []domain.Injection {
+	rebased := make([]domain.Injection, len(injections))
+	for i, injection := range injections {
+		injection.SourceFile = rebase(injection.SourceFile)
+		rebased[i] = injection
+	}
+	return rebased
+}
+
+func containsFolder(folders []domain.BaseFolder, destinationDir string) bool {
+	for _, v := range folders {
+		if v.DestinationDir == destinationDir {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFile(files []domain.BaseFile, destinationFile string) bool {
+	for _, v := range files {
+		if v.DestinationFile == destinationFile {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInstallationBase(installations []domain.InstallationsBase, name string) bool {
+	for _, v := range installations {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInstallationCommand(commands []domain.InstallationCommand, name string) bool {
+	for _, v := range commands {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsComponentCommand(commands []domain.ComponentCommands, kind string) bool {
+	for _, v := range commands {
+		if v.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
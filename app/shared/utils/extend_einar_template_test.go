@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestExtendEinarTemplate(t *testing.T) {
+	parent := domain.EinarTemplate{
+		BaseTemplate: domain.BaseTemplate{
+			Description: "base",
+			Files: []domain.BaseFile{
+				{SourceFile: "main.go", DestinationFile: "main.go"},
+				{SourceFile: ".gitignore", DestinationFile: ".gitignore"},
+			},
+		},
+		InstallationsBase: []domain.InstallationsBase{{Name: "echo", Library: "github.com/labstack/echo/v4"}},
+		InstallationCommands: []domain.InstallationCommand{
+			{Name: "pubsub", SourceDir: "app/shared/archetype/pubsub"},
+			{Name: "firestore", SourceDir: "app/shared/archetype/firestore"},
+		},
+		ComponentCommands: []domain.ComponentCommands{
+			{Kind: "subscription", ComponentFiles: []domain.ComponentFile{{SourceFile: "app/adapter/in/subscription.go"}}},
+			{Kind: "view", ComponentFiles: []domain.ComponentFile{{SourceFile: "app/adapter/in/view.go"}}},
+		},
+	}
+	child := domain.EinarTemplate{
+		Extends: &domain.Extends{
+			URL: "https://github.com/platform/base",
+			Tag: "v1.0.0",
+			Remove: domain.Removals{
+				InstallationCommands: []string{"firestore"},
+				Files:                []string{".gitignore"},
+			},
+		},
+		InstallationCommands: []domain.InstallationCommand{{Name: "redis", SourceDir: "app/shared/archetype/redis"}},
+		ComponentCommands: []domain.ComponentCommands{
+			{Kind: "view", ComponentFiles: []domain.ComponentFile{{SourceFile: "app/adapter/in/custom_view.go"}}},
+		},
+	}
+
+	parentDir := filepath.Join("..", "..", "platform", "base", "v1.0.0")
+	merged := ExtendEinarTemplate(parent, child, parentDir)
+
+	if merged.BaseTemplate.Description != "base" {
+		t.Errorf("expected the inherited description, got %q", merged.BaseTemplate.Description)
+	}
+	if len(merged.BaseTemplate.Files) != 1 || merged.BaseTemplate.Files[0].SourceFile != filepath.Join(parentDir, "main.go") {
+		t.Errorf("expected only main.go rebased into the parent folder, got %+v", merged.BaseTemplate.Files)
+	}
+	if len(merged.InstallationsBase) != 1 {
+		t.Errorf("expected the inherited base installation, got %+v", merged.InstallationsBase)
+	}
+
+	var names []string
+	for _, command := range merged.InstallationCommands {
+		names = append(names, command.Name)
+	}
+	if len(names) != 2 || names[0] != "pubsub" || names[1] != "redis" {
+		t.Errorf("expected pubsub and redis installations, got %v", names)
+	}
+	if merged.InstallationCommands[0].SourceDir != filepath.Join(parentDir, "app/shared/archetype/pubsub") {
+		t.Errorf("expected inherited source dir to be rebased, got %s", merged.InstallationCommands[0].SourceDir)
+	}
+	if merged.InstallationCommands[1].SourceDir != "app/shared/archetype/redis" {
+		t.Errorf("expected child source dir to be kept, got %s", merged.InstallationCommands[1].SourceDir)
+	}
+
+	if len(merged.ComponentCommands) != 2 {
+		t.Fatalf("expected subscription and view kinds, got %+v", merged.ComponentCommands)
+	}
+	if merged.ComponentCommands[1].ComponentFiles[0].SourceFile != "app/adapter/in/custom_view.go" {
+		t.Errorf("expected the child view to override the parent one, got %+v", merged.ComponentCommands[1])
+	}
+	if parent.ComponentCommands[0].ComponentFiles[0].SourceFile != "app/adapter/in/subscription.go" {
+		t.Errorf("expected the parent template to be left untouched")
+	}
+}
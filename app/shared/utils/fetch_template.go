@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"errors"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// FetchTemplate stores template in the template cache and returns its folder. Archive URLs
+// are unpacked, any other URL is cloned with git at the pinned commit, the ref or the tag.
+func FetchTemplate(template domain.Template, userCreds string) (string, error) {
+	if IsArchive(template.URL) {
+		if template.Ref != "" {
+			return "", errors.New("refs are only supported for git templates")
+		}
+		return FetchTemplateArchive(template.URL, userCreds, template.Tag)
+	}
+	if template.Commit != "" {
+		return GitCloneTemplateRefInBinaryPath(template.URL, userCreds, template.Commit)
+	}
+	if template.Ref != "" {
+		return GitCloneTemplateRefInBinaryPath(template.URL, userCreds, template.Ref)
+	}
+	return GitCloneTemplateInBinaryPath(template.URL, userCreds, template.Tag)
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// FetchTemplateArchive unpacks the template archive at archiveURL, a local path or an
+// http(s) URL, into the template cache under the tag declared by its manifest.
+// When tag is not empty it must match the manifest tag.
+func FetchTemplateArchive(archiveURL, userCreds, tag string) (string, error) {
+	targetPath, err := GetTemplateFolderPath(archiveURL)
+	if err != nil {
+		return "", err
+	}
+
+	archivePath := strings.TrimPrefix(archiveURL, LocalTemplateScheme)
+	if strings.HasPrefix(archiveURL, "http://") || strings.HasPrefix(archiveURL, "https://") {
+		archivePath, err = downloadArchive(archiveURL, userCreds)
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(archivePath)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "einar-archive-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := ExtractArchive(archivePath, tmpDir); err != nil {
+		return "", err
+	}
+
+	templateRoot, err := archiveRoot(tmpDir)
+	if err != nil {
+		return "", err
+	}
+	manifest, err := ReadArchiveManifest(templateRoot)
+	if err != nil {
+		return "", err
+	}
+	if manifest.Tag == "" {
+		return "", fmt.Errorf("%s of %s does not declare a tag", domain.ArchiveManifestFile, archiveURL)
+	}
+	if tag != "" && tag != manifest.Tag {
+		return "", fmt.Errorf("archive %s holds tag %s, expected %s", archiveURL, manifest.Tag, tag)
+	}
+
+	tagFolderPath, err := CacheTagPath(targetPath, manifest.Tag)
+	if err != nil {
+		return "", err
+	}
+	err = InstallCacheEntry(tagFolderPath, false, func(dir string) error {
+		return moveDirectoryContents(templateRoot, dir)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	Info("template archive unpacked", "path", tagFolderPath)
+	return tagFolderPath, nil
+}
+
+// ReadArchiveManifest reads the archive manifest found in dir.
+func ReadArchiveManifest(dir string) (domain.ArchiveManifest, error) {
+	var manifest domain.ArchiveManifest
+	content, err := os.ReadFile(filepath.Join(dir, domain.ArchiveManifestFile))
+	if err != nil {
+		return manifest, fmt.Errorf("error reading %s: %v", domain.ArchiveManifestFile, err)
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("error unmarshalling %s: %v", domain.ArchiveManifestFile, err)
+	}
+	return manifest, nil
+}
+
+// archiveRoot returns the folder holding the manifest, descending into the single top level
+// folder archives created from a directory usually have.
+func archiveRoot(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, domain.ArchiveManifestFile)); err == nil {
+		return dir, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return archiveRoot(filepath.Join(dir, entries[0].Name()))
+	}
+	return "", errors.New(domain.ArchiveManifestFile + " not found in archive")
+}
+
+func downloadArchive(archiveURL, userCreds string) (string, error) {
+	request, err := http.NewRequest(http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if userCreds != "" && userCreds != "no-auth" {
+		user, token, err := SplitCredentials(userCreds)
+		if err != nil {
+			return "", err
+		}
+		request.SetBasicAuth(user, token)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error downloading %s: %v", archiveURL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error downloading %s: unexpected status %s", archiveURL, response.Status)
+	}
+
+	pattern := "einar-archive-*" + filepath.Ext(archiveURL)
+	if strings.HasSuffix(strings.ToLower(archiveURL), ".tar.gz") {
+		pattern = "einar-archive-*.tar.gz"
+	}
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, response.Body); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("error downloading %s: %v", archiveURL, err)
+	}
+	return file.Name(), nil
+}
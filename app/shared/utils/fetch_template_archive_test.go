@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestFetchTemplateArchive(t *testing.T) {
+	srcDir := t.TempDir()
+	files := map[string]string{
+		domain.ArchiveManifestFile: `{"tag": "v1.0.0"}`,
+		".einar.template.json":     `{"installation_commands": [{"name": "echo-server"}]}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	archivePath := filepath.Join(t.TempDir(), "tpl.tar.gz")
+	if err := CreateTarGz(srcDir, archivePath); err != nil {
+		t.Fatal(err)
+	}
+	template := domain.Template{URL: LocalTemplateScheme + filepath.ToSlash(archivePath), Tag: "v1.0.0"}
+	repositoryPath, err := GetTemplateFolderPath(template.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(repositoryPath) })
+
+	fetchedPath, err := FetchTemplate(template, "no-auth")
+	if err != nil {
+		t.Fatalf("FetchTemplate() error = %v", err)
+	}
+	templateFolderPath, err := TemplateFolderPath(template)
+	if err != nil || templateFolderPath != fetchedPath {
+		t.Errorf("TemplateFolderPath() = %s, %v, want the fetched folder %s", templateFolderPath, err, fetchedPath)
+	}
+	if !IsTemplateCached(template) {
+		t.Errorf("IsTemplateCached() = false after fetching the archive")
+	}
+	einarTemplate, err := ReadEinarTemplateFromBinaryPath(templateFolderPath)
+	if err != nil || len(einarTemplate.InstallationCommands) != 1 {
+		t.Errorf("ReadEinarTemplateFromBinaryPath() = %v, %v", einarTemplate, err)
+	}
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"io/fs"
+	"os"
+)
+
+// FileSystem is what einar reads and writes project files, .einar.cli.json, main.go and
+// templates through. Only these still happen on disk: populating the template cache and
+// bundling templates, running go and git commands, the scratch projects of test-template
+// and Plan, and type-checking the packages imported by ports in LoadPorts. So only local
+// templates can be used on another filesystem.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	Rename(oldpath, newpath string) error
+}
+
+// FS is the filesystem einar works on, the disk unless replaced, e.g. by an in-memory
+// filesystem from NewMemFileSystem in tests. Relative paths are resolved against the
+// working directory.
+var FS FileSystem = osFileSystem{}
+
+type osFileSystem struct{}
+
+func (osFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFileSystem) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (osFileSystem) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (osFileSystem) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"path/filepath"
+)
+
+// FindUp returns the nearest folder, starting at dir and walking up to the filesystem
+// root, that holds a file called name.
+func FindUp(dir, name string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		if _, err := FS.Stat(filepath.Join(dir, name)); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
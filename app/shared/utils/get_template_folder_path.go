@@ -29,6 +29,9 @@ func GetTemplateFolderPath(repositoryUrl string) (string, error) {
 	// Create a suitable file system path from the repository URL
 	repositoryPath := strings.TrimPrefix(u.Path, "/")
 	repositoryPath = strings.TrimSuffix(repositoryPath, ".git")
+	for _, extension := range []string{".tar.gz", ".tgz", ".zip"} {
+		repositoryPath = strings.TrimSuffix(repositoryPath, extension)
+	}
 
 	// Combine host and path
 	repositoryPath = filepath.Join(u.Host, repositoryPath)
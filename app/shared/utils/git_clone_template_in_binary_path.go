@@ -8,97 +8,57 @@ import (
 	"path/filepath"
 	"syscall"
 
-	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
+// GitCloneTemplateInBinaryPath caches the template at tag, the latest tag when empty. Only
+// the tagged commit is fetched, into the bare mirror of the template, and its files are
+// written to the cache without the .git folder.
 func GitCloneTemplateInBinaryPath(repositoryUrl, userCreds, tag string) (string, error) {
 	targetPath, err := GetTemplateFolderPath(repositoryUrl)
 	if err != nil {
-		fmt.Println(err)
 		return "", err
 	}
 
-	var auth *http.BasicAuth
-	if userCreds != "no-auth" {
-		user, token, err := SplitCredentials(userCreds)
-		if err != nil {
-			fmt.Println("Failed to parse user credentials:", err)
-			return "", err
-		}
-		auth = &http.BasicAuth{Username: user, Password: token}
-	}
-
-	// Clonar en un directorio temporal
-	tmpDir, err := ioutil.TempDir("", "git-")
-	if err != nil {
-		return "", fmt.Errorf("error creating temp dir: %v", err)
-	}
-	defer os.RemoveAll(tmpDir) // Limpia el directorio temporal después
-
-	_, err = git.PlainClone(tmpDir, false, &git.CloneOptions{
-		URL:      repositoryUrl,
-		Progress: os.Stdout,
-		Auth:     auth,
-	})
+	mirror, err := openTemplateMirror(repositoryUrl, userCreds, targetPath)
 	if err != nil {
-		fmt.Println("Failed to clone repository into temp folder:", err)
-		return "", err
-	}
-
-	// Abrir el repositorio clonado
-	repo, err := git.PlainOpen(tmpDir)
-	if err != nil {
-		fmt.Println("Failed to open repository:", err)
 		return "", err
 	}
+	defer mirror.close()
 
 	effectiveTag := tag
 	if tag == "" {
 		// Obtén el tag más reciente si no se proporciona uno
-		tagRefs, err := repo.Tags()
+		effectiveTag, err = mirror.latestTag()
 		if err != nil {
-			fmt.Println("Failed to list tags:", err)
 			return "", err
 		}
+	}
 
-		err = tagRefs.ForEach(func(ref *plumbing.Reference) error {
-			effectiveTag = ref.Name().Short()
-			return nil
-		})
-		if err != nil {
-			fmt.Println("Failed to iterate over tags:", err)
-			return "", err
-		}
+	tagFolderPath := filepath.Join(targetPath, effectiveTag)
+	if IsCacheEntryComplete(tagFolderPath) {
+		return tagFolderPath, nil
 	}
 
-	w, err := repo.Worktree()
+	tagRef := plumbing.NewTagReferenceName(effectiveTag)
+	refSpec := config.RefSpec(fmt.Sprintf("+%s:%s", tagRef, tagRef))
+	if err := mirror.fetch(1, refSpec); err != nil {
+		return "", fmt.Errorf("failed to fetch tag %s: %w", effectiveTag, err)
+	}
+	hash, err := mirror.repo.ResolveRevision(plumbing.Revision(tagRef))
 	if err != nil {
-		fmt.Println("Failed to get worktree:", err)
-		return "", err
+		return "", fmt.Errorf("failed to resolve tag %s: %w", effectiveTag, err)
 	}
 
-	err = w.Checkout(&git.CheckoutOptions{
-		Branch: plumbing.ReferenceName(fmt.Sprintf("refs/tags/%s", effectiveTag)),
+	err = InstallCacheEntry(tagFolderPath, false, func(dir string) error {
+		return mirror.writeTree(*hash, dir)
 	})
 	if err != nil {
-		fmt.Println("Failed to checkout tag:", err)
-		return "", err
-	}
-
-	// Mover contenido del directorio temporal al directorio final
-	tagFolderPath := filepath.Join(targetPath, effectiveTag)
-	if err := os.MkdirAll(tagFolderPath, os.ModePerm); err != nil {
-		fmt.Println("Failed to create tag folder:", err)
-		return "", err
-	}
-	if err := moveDirectoryContents(tmpDir, tagFolderPath); err != nil {
-		fmt.Println("Failed to move repository content:", err)
-		return "", err
+		return "", fmt.Errorf("failed to write template files: %w", err)
 	}
 
-	fmt.Println("Repository cloned to:", tagFolderPath)
+	Info("template repository cloned", "path", tagFolderPath)
 	return tagFolderPath, nil
 }
 
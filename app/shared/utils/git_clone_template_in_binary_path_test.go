@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestGitCloneTemplateInBinaryPath(t *testing.T) {
+	repositoryPath := filepath.Join(t.TempDir(), "template")
+	repo, err := git.PlainInit(repositoryPath, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, _ := repo.Worktree()
+	commit := func(content string) plumbing.Hash {
+		if err := os.WriteFile(filepath.Join(repositoryPath, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		worktree.Add("main.go")
+		hash, err := worktree.Commit(content, &git.CommitOptions{
+			Author: &object.Signature{Name: "einar", Email: "einar@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hash
+	}
+	first := commit("package main // v1.0.0\n")
+	repo.CreateTag("v1.0.0", first, nil)
+	repo.CreateTag("v1.2.0", commit("package main // v1.2.0\n"), nil)
+	t.Cleanup(func() {
+		targetPath, _ := GetTemplateFolderPath(repositoryPath)
+		os.RemoveAll(targetPath)
+	})
+
+	tagFolderPath, err := GitCloneTemplateInBinaryPath(repositoryPath, "no-auth", "")
+	if err != nil {
+		t.Fatalf("GitCloneTemplateInBinaryPath() error = %v", err)
+	}
+	if filepath.Base(tagFolderPath) != "v1.2.0" {
+		t.Errorf("GitCloneTemplateInBinaryPath() cached %s, want the latest tag v1.2.0", tagFolderPath)
+	}
+	if _, err := os.Stat(filepath.Join(tagFolderPath, ".git")); !os.IsNotExist(err) {
+		t.Errorf("GitCloneTemplateInBinaryPath() kept the .git folder in the cache")
+	}
+
+	commitFolderPath, err := GitCloneTemplateRefInBinaryPath(repositoryPath, "no-auth", first.String())
+	if err != nil {
+		t.Fatalf("GitCloneTemplateRefInBinaryPath() error = %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(commitFolderPath, "main.go"))
+	if string(content) != "package main // v1.0.0\n" {
+		t.Errorf("GitCloneTemplateRefInBinaryPath() cached main.go = %q", content)
+	}
+}
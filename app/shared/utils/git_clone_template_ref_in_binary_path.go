@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// GitCloneTemplateRefInBinaryPath caches the template at ref, a branch name or a commit SHA,
+// in a folder named after the commit ref resolves to, and returns that folder. Branches are
+// fetched at depth 1, commits need the history of every branch and tag.
+func GitCloneTemplateRefInBinaryPath(repositoryUrl, userCreds, ref string) (string, error) {
+	targetPath, err := GetTemplateFolderPath(repositoryUrl)
+	if err != nil {
+		return "", err
+	}
+
+	mirror, err := openTemplateMirror(repositoryUrl, userCreds, targetPath)
+	if err != nil {
+		return "", err
+	}
+	defer mirror.close()
+
+	branchRef := plumbing.NewRemoteReferenceName(git.DefaultRemoteName, ref)
+	branchSpec := config.RefSpec(fmt.Sprintf("+%s:%s", plumbing.NewBranchReferenceName(ref), branchRef))
+	hash, err := resolveTemplateRef(mirror.repo, ref)
+	if fetchErr := mirror.fetch(1, branchSpec); fetchErr == nil {
+		hash, err = resolveTemplateRef(mirror.repo, ref)
+	} else if err != nil {
+		if err := mirror.reset(); err != nil {
+			return "", err
+		}
+		err = mirror.fetch(0,
+			config.RefSpec("+refs/heads/*:refs/remotes/origin/*"),
+			config.RefSpec("+refs/tags/*:refs/tags/*"))
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch %s: %v", ref, err)
+		}
+		hash, err = resolveTemplateRef(mirror.repo, ref)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	commitFolderPath := filepath.Join(targetPath, hash.String())
+	err = InstallCacheEntry(commitFolderPath, false, func(dir string) error {
+		return mirror.writeTree(*hash, dir)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to write template files: %v", err)
+	}
+
+	Info("template repository cloned", "path", commitFolderPath)
+	return commitFolderPath, nil
+}
+
+// resolveTemplateRef resolves ref as a remote branch first and as any other revision,
+// such as a commit SHA, otherwise.
+func resolveTemplateRef(repo *git.Repository, ref string) (*plumbing.Hash, error) {
+	if hash, err := repo.ResolveRevision(plumbing.Revision(plumbing.NewRemoteReferenceName(git.DefaultRemoteName, ref))); err == nil {
+		return hash, nil
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("ref %s not found in template repository: %v", ref, err)
+	}
+	return hash, nil
+}
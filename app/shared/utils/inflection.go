@@ -0,0 +1,116 @@
+package utils
+
+import "strings"
+
+var irregularPlurals = map[string]string{
+	"analysis": "analyses",
+	"child":    "children",
+	"crisis":   "crises",
+	"foot":     "feet",
+	"goose":    "geese",
+	"index":    "indices",
+	"knife":    "knives",
+	"leaf":     "leaves",
+	"life":     "lives",
+	"man":      "men",
+	"matrix":   "matrices",
+	"mouse":    "mice",
+	"person":   "people",
+	"tooth":    "teeth",
+	"vertex":   "vertices",
+	"wife":     "wives",
+	"woman":    "women",
+
+	// plurals the suffix rules get wrong in one direction or the other
+	"alias":  "aliases",
+	"abuse":  "abuses",
+	"canvas": "canvases",
+	"cookie": "cookies",
+	"echo":   "echoes",
+	"excuse": "excuses",
+	"fuse":   "fuses",
+	"gas":    "gases",
+	"hero":   "heroes",
+	"movie":  "movies",
+	"potato": "potatoes",
+	"quiz":   "quizzes",
+	"refuse": "refuses",
+	"tomato": "tomatoes",
+	"use":    "uses",
+	"veto":   "vetoes",
+	"whiz":   "whizzes",
+	"zombie": "zombies",
+}
+
+var uncountables = map[string]bool{
+	"data":        true,
+	"equipment":   true,
+	"information": true,
+	"metadata":    true,
+	"money":       true,
+	"news":        true,
+	"series":      true,
+	"species":     true,
+}
+
+// Pluralize returns the English plural of a lowercase word. Words that are already plural
+// are returned as they are.
+func Pluralize(word string) string {
+	if uncountables[word] {
+		return word
+	}
+	if plural, ok := irregularPlurals[word]; ok {
+		return plural
+	}
+	if singular := Singularize(word); singular != word && pluralize(singular) == word {
+		return word
+	}
+	return pluralize(word)
+}
+
+func pluralize(word string) string {
+	if plural, ok := irregularPlurals[word]; ok {
+		return plural
+	}
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !isVowel(word[len(word)-2]):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
+
+// Singularize returns the English singular of a lowercase word.
+func Singularize(word string) string {
+	if uncountables[word] {
+		return word
+	}
+	for singular, plural := range irregularPlurals {
+		if plural == word {
+			return singular
+		}
+	}
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "uses") && len(word) > 4 && !isVowel(word[len(word)-5]):
+		// statuses and buses, while causes and houses only drop the s
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return word[:len(word)-1]
+	default:
+		return word
+	}
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	injectMarker = "einar:inject"
+	beginMarker  = "einar:begin"
+	endMarker    = "einar:end"
+)
+
+// InjectSnippet inserts snippet right above the `einar:inject <marker>` comment of filePath.
+// The snippet is wrapped between `einar:begin <key>` and `einar:end <key>` comments written
+// with the same comment syntax as the marker, so running it again with the same key is a no-op.
+func InjectSnippet(filePath, marker, key, snippet string) error {
+	content, err := FS.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(content), "\n")
+
+	if _, _, found := findInjectedBlock(lines, key); found {
+		return nil
+	}
+
+	for i, line := range lines {
+		prefix, suffix, ok := splitMarkerLine(line, injectMarker, marker)
+		if !ok {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		block := []string{indent + prefix + beginMarker + " " + key + suffix}
+		for _, snippetLine := range strings.Split(strings.TrimRight(snippet, "\n"), "\n") {
+			if snippetLine == "" {
+				block = append(block, "")
+				continue
+			}
+			block = append(block, indent+snippetLine)
+		}
+		block = append(block, indent+prefix+endMarker+" "+key+suffix)
+
+		updated := append([]string{}, lines[:i]...)
+		updated = append(updated, block...)
+		updated = append(updated, lines[i:]...)
+		return FS.WriteFile(filePath, []byte(strings.Join(updated, "\n")), 0644)
+	}
+
+	return fmt.Errorf("injection marker %q not found in %s", injectMarker+" "+marker, filePath)
+}
+
+// RemoveInjectedSnippet removes the block previously injected with key from filePath.
+// The file is left untouched when no block with that key exists.
+func RemoveInjectedSnippet(filePath, key string) error {
+	content, err := FS.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(content), "\n")
+
+	begin, end, found := findInjectedBlock(lines, key)
+	if !found {
+		return nil
+	}
+	if end < 0 {
+		return fmt.Errorf("injected block %q has no %s comment in %s", key, endMarker, filePath)
+	}
+
+	updated := append([]string{}, lines[:begin]...)
+	updated = append(updated, lines[end+1:]...)
+	return FS.WriteFile(filePath, []byte(strings.Join(updated, "\n")), 0644)
+}
+
+// findInjectedBlock returns the lines of the begin and end comments of the block injected
+// with key, end being -1 when the block is not closed.
+func findInjectedBlock(lines []string, key string) (int, int, bool) {
+	begin := -1
+	for i, line := range lines {
+		if _, _, ok := splitMarkerLine(line, beginMarker, key); ok && begin < 0 {
+			begin = i
+			continue
+		}
+		if _, _, ok := splitMarkerLine(line, endMarker, key); ok && begin >= 0 {
+			return begin, i, true
+		}
+	}
+	return begin, -1, begin >= 0
+}
+
+// splitMarkerLine reports whether line holds `<directive> <name>` and returns the comment
+// syntax surrounding it, e.g. "// " and "" or "<!-- " and " -->".
+func splitMarkerLine(line, directive, name string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	idx := strings.Index(trimmed, directive+" ")
+	if idx < 0 {
+		return "", "", false
+	}
+	rest := trimmed[idx+len(directive)+1:]
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || fields[0] != name {
+		return "", "", false
+	}
+	suffix := strings.TrimPrefix(rest, name)
+	if suffix != "" {
+		suffix = " " + strings.TrimSpace(suffix)
+	}
+	return trimmed[:idx], suffix, true
+}
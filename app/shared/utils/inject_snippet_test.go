@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInjectSnippet(t *testing.T) {
+	source := `package router
+
+func routes() {
+	// einar:inject routes
+}
+`
+	want := `package router
+
+func routes() {
+	// einar:begin get-controller/get-customer:route
+	e.GET("/customer", getCustomer)
+	// einar:end get-controller/get-customer:route
+	// einar:inject routes
+}
+`
+	filePath := filepath.Join(t.TempDir(), "router.go")
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write router.go: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err := InjectSnippet(filePath, "routes", "get-controller/get-customer:route", `e.GET("/customer", getCustomer)`+"\n")
+		if err != nil {
+			t.Fatalf("InjectSnippet() error = %v", err)
+		}
+	}
+	got, _ := os.ReadFile(filePath)
+	if string(got) != want {
+		t.Errorf("InjectSnippet() got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInjectSnippetHTMLComment(t *testing.T) {
+	source := "<ul>\n  <!-- einar:inject links -->\n</ul>\n"
+	want := "<ul>\n  <!-- einar:begin home -->\n  <li>home</li>\n  <!-- einar:end home -->\n  <!-- einar:inject links -->\n</ul>\n"
+	filePath := filepath.Join(t.TempDir(), "layout.html")
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write layout.html: %v", err)
+	}
+	if err := InjectSnippet(filePath, "links", "home", "<li>home</li>"); err != nil {
+		t.Fatalf("InjectSnippet() error = %v", err)
+	}
+	got, _ := os.ReadFile(filePath)
+	if string(got) != want {
+		t.Errorf("InjectSnippet() got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInjectSnippetMissingMarker(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "router.go")
+	if err := os.WriteFile(filePath, []byte("package router\n"), 0644); err != nil {
+		t.Fatalf("Failed to write router.go: %v", err)
+	}
+	if err := InjectSnippet(filePath, "routes", "key", "snippet"); err == nil {
+		t.Errorf("InjectSnippet() expected an error for a missing marker")
+	}
+}
+
+func TestRemoveInjectedSnippet(t *testing.T) {
+	source := "package router\n\nfunc routes() {\n\t// einar:inject routes\n}\n"
+	injected := "package router\n\nfunc routes() {\n\t// einar:begin orders:route\n\te.GET(\"/orders\", nil)\n\t// einar:end orders:route\n\t// einar:inject routes\n}\n"
+	filePath := filepath.Join(t.TempDir(), "router.go")
+	if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write router.go: %v", err)
+	}
+
+	steps := []struct {
+		name  string
+		apply func() error
+		want  string
+	}{
+		{"inject", func() error { return InjectSnippet(filePath, "routes", "orders:route", `e.GET("/orders", nil)`) }, injected},
+		{"remove", func() error { return RemoveInjectedSnippet(filePath, "orders:route") }, source},
+		{"remove again", func() error { return RemoveInjectedSnippet(filePath, "orders:route") }, source},
+		{"inject again", func() error { return InjectSnippet(filePath, "routes", "orders:route", `e.GET("/orders", nil)`) }, injected},
+		{"inject twice", func() error { return InjectSnippet(filePath, "routes", "orders:route", `e.GET("/orders", nil)`) }, injected},
+	}
+	for _, step := range steps {
+		if err := step.apply(); err != nil {
+			t.Fatalf("%s: error = %v", step.name, err)
+		}
+		got, _ := os.ReadFile(filePath)
+		if string(got) != step.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", step.name, got, step.want)
+		}
+	}
+}
+
+func TestRemoveInjectedSnippetUnclosedBlock(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "router.go")
+	content := "package router\n\n// einar:begin orders:route\nfunc orders() {}\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write router.go: %v", err)
+	}
+	if err := RemoveInjectedSnippet(filePath, "orders:route"); err == nil {
+		t.Errorf("RemoveInjectedSnippet() expected an error for a block without its end comment")
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// ListBlankImports returns the paths imported with the blank identifier in the Go file at filePath.
+func ListBlankImports(filePath string) ([]string, error) {
+	src, err := FS.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filePath, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	for _, spec := range file.Imports {
+		if spec.Name == nil || spec.Name.Name != "_" {
+			continue
+		}
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, path)
+	}
+	return imports, nil
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ListExecutables returns the executables on PATH whose name starts with prefix, in PATH
+// order. On Windows executables are recognized by the extensions listed in PATHEXT.
+func ListExecutables(prefix string) []string {
+	var executables []string
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), prefix) || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if isExecutable(path) {
+				executables = append(executables, path)
+			}
+		}
+	}
+	return executables
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS != "windows" {
+		return info.Mode().Perm()&0111 != 0
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".com;.exe;.bat;.cmd"
+	}
+	for _, candidate := range strings.Split(strings.ToLower(pathExt), ";") {
+		if candidate != "" && candidate == ext {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"path"
+	"path/filepath"
+)
+
+// ListFiles returns the slash separated paths, relative to dir, of every file below dir.
+func ListFiles(dir string) ([]string, error) {
+	var files []string
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		entries, err := FS.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			entryPath := path.Join(rel, entry.Name())
+			if !entry.IsDir() {
+				files = append(files, entryPath)
+				continue
+			}
+			if err := walk(entryPath); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return files, walk("")
+}
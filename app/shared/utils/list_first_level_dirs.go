@@ -1,14 +1,13 @@
 package utils
 
 import (
-	"os"
 	"path/filepath"
 )
 
 func ListFirstLevelDirs(dirPath string) ([]string, error) {
 	var dirs []string
 
-	entries, err := os.ReadDir(dirPath)
+	entries, err := FS.ReadDir(dirPath)
 	if err != nil {
 		return nil, err
 	}
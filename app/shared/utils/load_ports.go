@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Port is a function type declared in a domain ports package, e.g. out.Shutdown.
+type Port struct {
+	Name      string
+	Package   *types.Package
+	Signature *types.Signature
+}
+
+// LoadPorts type-checks the Go package found in dir of the module modulePath and returns
+// the exported function types it declares, sorted by name. The package is read through FS,
+// the packages it imports are type-checked from their sources on disk.
+func LoadPorts(modulePath, dir string) ([]Port, error) {
+	entries, err := FS.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		filePath := filepath.Join(dir, name)
+		src, err := FS.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, filePath, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files found in %s", dir)
+	}
+
+	pkgPath := path.Join(modulePath, filepath.ToSlash(filepath.Clean(dir)))
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(pkgPath, fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error type checking package %s: %v", pkgPath, err)
+	}
+
+	scope := pkg.Scope()
+	var ports []Port
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !typeName.Exported() {
+			continue
+		}
+		signature, ok := typeName.Type().Underlying().(*types.Signature)
+		if !ok {
+			continue
+		}
+		ports = append(ports, Port{Name: name, Package: pkg, Signature: signature})
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i].Name < ports[j].Name })
+	return ports, nil
+}
+
+// LoadPort returns the function type portName declared in the package of portFile.
+func LoadPort(modulePath, portFile, portName string) (Port, error) {
+	ports, err := LoadPorts(modulePath, filepath.Dir(portFile))
+	if err != nil {
+		return Port{}, err
+	}
+	for _, port := range ports {
+		if port.Name == portName {
+			return port, nil
+		}
+	}
+	return Port{}, errors.New("function type " + portName + " not found in " + portFile)
+}
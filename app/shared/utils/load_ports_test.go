@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPorts(t *testing.T) {
+	dir := t.TempDir()
+	source := `package out
+
+import "context"
+
+type SaveOrder func(ctx context.Context, id string, quantities ...int) (int64, error)
+
+type FindOrders func(context.Context, string) ([]string, bool, error)
+
+type notExported func() error
+
+type Order struct{}
+`
+	if err := os.WriteFile(filepath.Join(dir, "ports.go"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ports, err := LoadPorts("shop", dir)
+	if err != nil {
+		t.Fatalf("LoadPorts() error = %v", err)
+	}
+	want := []struct {
+		name      string
+		signature string
+	}{
+		{"FindOrders", "func(context.Context, string) ([]string, bool, error)"},
+		{"SaveOrder", "func(ctx context.Context, id string, quantities ...int) (int64, error)"},
+	}
+	if len(ports) != len(want) {
+		t.Fatalf("LoadPorts() = %v, want %v", ports, want)
+	}
+	for i, w := range want {
+		if ports[i].Name != w.name || ports[i].Signature.String() != w.signature {
+			t.Errorf("LoadPorts()[%d] = %s %s, want %s %s", i, ports[i].Name, ports[i].Signature, w.name, w.signature)
+		}
+	}
+
+	if _, err := LoadPort("shop", filepath.Join(dir, "ports.go"), "DeleteOrder"); err == nil {
+		t.Error("LoadPort() of a missing port succeeded")
+	}
+}
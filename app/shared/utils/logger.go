@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// defaultLogger reports messages at info level and above on the standard error.
+var defaultLogger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+var logger = defaultLogger
+
+// SetLogger sends the messages of einar to l, or to the default logger when l is nil.
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = defaultLogger
+	}
+	logger = l
+}
+
+// Debug reports the details of what einar does, such as the paths it computes and the
+// placeholders it replaces.
+func Debug(msg string, args ...interface{}) {
+	logger.Debug(msg, args...)
+}
+
+// Info reports the progress of a command.
+func Info(msg string, args ...interface{}) {
+	logger.Info(msg, args...)
+}
+
+// Warn reports a problem that does not stop a command.
+func Warn(msg string, args ...interface{}) {
+	logger.Warn(msg, args...)
+}
+
+// LogWriter returns the writer for the output of the git and go commands einar runs: the
+// standard error when debug messages are enabled, nowhere otherwise.
+func LogWriter() io.Writer {
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		return os.Stderr
+	}
+	return io.Discard
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggerLevels(t *testing.T) {
+	tests := []struct {
+		name        string
+		level       slog.Level
+		wantLogs    []string
+		wantDiscard bool
+	}{
+		{"debug", slog.LevelDebug, []string{"placeholder replaced", "file copied", "warning"}, false},
+		{"info", slog.LevelInfo, []string{"warning"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: tt.level})))
+			defer SetLogger(nil)
+			previous := FS
+			FS = NewMemFileSystem()
+			defer func() { FS = previous }()
+			if err := FS.WriteFile("template.go", []byte("package archetype\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := CopyFile("template.go", "app/shop.go", []string{"archetype"}, []string{"shop"}); err != nil {
+				t.Fatal(err)
+			}
+			Warn("warning")
+
+			if got := strings.Count(logs.String(), "\n"); got != len(tt.wantLogs) {
+				t.Errorf("logged %d messages, want %d:\n%s", got, len(tt.wantLogs), logs.String())
+			}
+			for _, want := range tt.wantLogs {
+				if !strings.Contains(logs.String(), want) {
+					t.Errorf("logs = %s, want %q", logs.String(), want)
+				}
+			}
+			if got := LogWriter() == io.Discard; got != tt.wantDiscard {
+				t.Errorf("LogWriter() discards = %v, want %v", got, tt.wantDiscard)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"io/fs"
+	"sort"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+)
+
+// NewMemFileSystem returns an empty in-memory FileSystem. Relative paths are resolved
+// against its root, so "main.go" and "/main.go" are the same file.
+func NewMemFileSystem() FileSystem {
+	return memFileSystem{fs: memfs.New()}
+}
+
+type memFileSystem struct {
+	fs billy.Filesystem
+}
+
+func (m memFileSystem) ReadFile(name string) ([]byte, error) {
+	content, err := util.ReadFile(m.fs, name)
+	return content, pathError("open", name, err)
+}
+
+func (m memFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return pathError("open", name, util.WriteFile(m.fs, name, data, perm))
+}
+
+func (m memFileSystem) Stat(name string) (fs.FileInfo, error) {
+	info, err := m.fs.Stat(name)
+	return info, pathError("stat", name, err)
+}
+
+func (m memFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	// memfs lists missing folders as empty ones
+	if _, err := m.Stat(name); err != nil {
+		return nil, err
+	}
+	infos, err := m.fs.ReadDir(name)
+	if err != nil {
+		return nil, pathError("readdir", name, err)
+	}
+	entries := make([]fs.DirEntry, 0, len(infos))
+	for _, info := range infos {
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (m memFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	return pathError("mkdir", path, m.fs.MkdirAll(path, perm))
+}
+
+func (m memFileSystem) Remove(name string) error {
+	return pathError("remove", name, m.fs.Remove(name))
+}
+
+func (m memFileSystem) Rename(oldpath, newpath string) error {
+	return pathError("rename", oldpath, m.fs.Rename(oldpath, newpath))
+}
+
+// pathError wraps the plain errors of memfs like the os package does, so they can be
+// told apart with os.IsNotExist and are reported as filesystem errors.
+func pathError(op, path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*fs.PathError); ok {
+		return err
+	}
+	return &fs.PathError{Op: op, Path: path, Err: err}
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// einarCliMigrations upgrades a decoded .einar.cli.json file: the migration at index i
+// turns schema version i into version i+1.
+var einarCliMigrations = []func(config map[string]interface{}) error{
+	migrateEinarCliV0,
+}
+
+// migrateEinarCliV0 upgrades unversioned files. The version placeholder and the template
+// id written by the first einar releases are not used anymore.
+func migrateEinarCliV0(config map[string]interface{}) error {
+	delete(config, "version")
+	if template, ok := config["template"].(map[string]interface{}); ok {
+		delete(template, "id")
+	}
+	return nil
+}
+
+// MigrateEinarCli upgrades the .einar.cli.json file at path to the current schema version
+// in place, keeping the original content in a backup file next to it.
+func MigrateEinarCli(path string) (domain.ConfigMigration, error) {
+	migration := domain.ConfigMigration{To: domain.CurrentSchemaVersion}
+	content, err := FS.ReadFile(path)
+	if err != nil {
+		return migration, err
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return migration, fmt.Errorf("%s: %s", path, describeJSONError(content, err))
+	}
+	migration.From, err = einarCliSchemaVersion(config)
+	if err != nil {
+		return migration, fmt.Errorf("%s: %v", path, err)
+	}
+	if migration.From > domain.CurrentSchemaVersion {
+		return migration, fmt.Errorf("%s uses schema version %d but this einar only supports up to %d, upgrade einar",
+			path, migration.From, domain.CurrentSchemaVersion)
+	}
+	if !migration.Migrated() {
+		return migration, nil
+	}
+
+	for version := migration.From; version < domain.CurrentSchemaVersion; version++ {
+		if err := einarCliMigrations[version](config); err != nil {
+			return migration, fmt.Errorf("error migrating %s from schema version %d: %v", path, version, err)
+		}
+	}
+	config["schema_version"] = domain.CurrentSchemaVersion
+
+	migratedConfig, err := json.Marshal(config)
+	if err != nil {
+		return migration, err
+	}
+	cli, err := decodeEinarCli(migratedConfig)
+	if err != nil {
+		return migration, fmt.Errorf("%s after migrating from schema version %d: %v", path, migration.From, err)
+	}
+	migrated, err := json.MarshalIndent(cli, "", "  ")
+	if err != nil {
+		return migration, err
+	}
+	migration.Backup = fmt.Sprintf("%s.v%d.bak", path, migration.From)
+	if err := FS.WriteFile(migration.Backup, content, 0644); err != nil {
+		return migration, fmt.Errorf("error writing backup %s: %v", migration.Backup, err)
+	}
+	if err := FS.WriteFile(path, migrated, 0644); err != nil {
+		return migration, err
+	}
+	return migration, nil
+}
+
+func einarCliSchemaVersion(config map[string]interface{}) (int, error) {
+	value, ok := config["schema_version"]
+	if !ok {
+		return 0, nil
+	}
+	version, ok := value.(float64)
+	if !ok || version < 0 || version != float64(int(version)) {
+		return 0, fmt.Errorf("schema_version must be a positive integer, got %v", value)
+	}
+	return int(version), nil
+}
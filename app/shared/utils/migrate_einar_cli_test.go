@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestMigrateEinarCli(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantFrom   int
+		wantBackup bool
+		wantErr    string
+	}{
+		{
+			name:       "unversioned file with legacy fields",
+			content:    `{"version": "${version}", "project": "app", "template": {"id": "d111", "url": "https://github.com/x/tpl"}}`,
+			wantFrom:   0,
+			wantBackup: true,
+		},
+		{
+			name:     "current schema version",
+			content:  `{"schema_version": 1, "project": "app", "template": {"url": "https://github.com/x/tpl", "tag": "v1.0.0"}}`,
+			wantFrom: 1,
+		},
+		{
+			name:    "newer schema version",
+			content: `{"schema_version": 2, "project": "app"}`,
+			wantErr: "upgrade einar",
+		},
+		{
+			name:    "unknown field left after migration",
+			content: `{"project": "app", "componets": []}`,
+			wantErr: `unknown field "componets"`,
+		},
+		{
+			name:    "syntax error",
+			content: "{\n  \"project\": \"app\",\n}",
+			wantErr: "line 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".einar.cli.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			migration, err := MigrateEinarCli(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("MigrateEinarCli() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MigrateEinarCli() error = %v", err)
+			}
+			if migration.From != tt.wantFrom || migration.To != domain.CurrentSchemaVersion {
+				t.Errorf("MigrateEinarCli() = %+v, want from %d", migration, tt.wantFrom)
+			}
+			if (migration.Backup != "") != tt.wantBackup {
+				t.Errorf("MigrateEinarCli() backup = %q, want backup %v", migration.Backup, tt.wantBackup)
+			}
+
+			content, _ := os.ReadFile(path)
+			if _, err := decodeEinarCli(content); err != nil {
+				t.Errorf("migrated file does not decode strictly: %v", err)
+			}
+		})
+	}
+}
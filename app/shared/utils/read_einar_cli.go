@@ -1,24 +1,111 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
-	"io/ioutil"
-	"os"
+	"errors"
+	"fmt"
+	"io/fs"
 
 	"github.com/Ignaciojeria/einar/app/domain"
 )
 
+// ReadEinarCli reads the .einar.cli.json file of the current project, migrating it to the
+// current schema version first. Unknown fields are reported instead of being dropped, and a
+// missing file is reported as domain.ErrorNotInitialized.
 func ReadEinarCli() (domain.EinarCli, error) {
-	jsonFile, err := os.Open(".einar.cli.json")
+	return ReadEinarCliFile(".einar.cli.json")
+}
+
+// ReadEinarCliFile reads the .einar.cli.json file at path like ReadEinarCli.
+func ReadEinarCliFile(path string) (domain.EinarCli, error) {
+	migration, err := MigrateEinarCli(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.EinarCli{}, &domain.Error{Code: domain.ErrorNotInitialized, Err: err}
+	}
 	if err != nil {
 		return domain.EinarCli{}, err
 	}
-	defer jsonFile.Close()
+	if migration.Migrated() {
+		Info("configuration migrated", "file", path, "from", migration.From, "to", migration.To, "backup", migration.Backup)
+	}
 
-	byteValue, _ := ioutil.ReadAll(jsonFile)
+	content, err := FS.ReadFile(path)
+	if err != nil {
+		return domain.EinarCli{}, err
+	}
+	config, err := decodeEinarCli(content)
+	if err != nil {
+		return domain.EinarCli{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return config, nil
+}
 
-	var config domain.EinarCli
-	json.Unmarshal(byteValue, &config)
+// PeekEinarCli reads the .einar.cli.json file of the current project like ReadEinarCli,
+// without ever writing it: files of another schema version are reported instead of being
+// migrated.
+func PeekEinarCli() (domain.EinarCli, error) {
+	const path = ".einar.cli.json"
+	content, err := FS.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.EinarCli{}, &domain.Error{Code: domain.ErrorNotInitialized, Err: err}
+	}
+	if err != nil {
+		return domain.EinarCli{}, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return domain.EinarCli{}, fmt.Errorf("%s: %s", path, describeJSONError(content, err))
+	}
+	version, err := einarCliSchemaVersion(fields)
+	if err != nil {
+		return domain.EinarCli{}, fmt.Errorf("%s: %v", path, err)
+	}
+	if version != domain.CurrentSchemaVersion {
+		return domain.EinarCli{}, fmt.Errorf("%s uses schema version %d, expected %d, run einar migrate-config",
+			path, version, domain.CurrentSchemaVersion)
+	}
+	config, err := decodeEinarCli(content)
+	if err != nil {
+		return domain.EinarCli{}, fmt.Errorf("%s: %v", path, err)
+	}
+	return config, nil
+}
 
+// decodeEinarCli decodes content rejecting unknown fields.
+func decodeEinarCli(content []byte) (domain.EinarCli, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var config domain.EinarCli
+	if err := decoder.Decode(&config); err != nil {
+		return domain.EinarCli{}, errors.New(describeJSONError(content, err))
+	}
 	return config, nil
 }
+
+// describeJSONError adds the line and column of syntax and type errors found decoding
+// content.
+func describeJSONError(content []byte, err error) string {
+	var offset int64 = -1
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+	if offset < 0 {
+		return err.Error()
+	}
+	line, column := 1, 1
+	for _, b := range content[:offset] {
+		if b == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	return fmt.Sprintf("line %d, column %d: %v", line, column, err)
+}
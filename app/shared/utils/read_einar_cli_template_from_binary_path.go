@@ -3,18 +3,34 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/Ignaciojeria/einar/app/domain"
 )
 
+// maxExtendsDepth bounds the chain of templates followed through extends.
+const maxExtendsDepth = 10
+
+// ReadEinarTemplateFromBinaryPath reads the .einar.template.json of templateFolder.
+// When the template extends another one, the parent is read from its cache folder,
+// cloning it when missing, and merged under the child.
 func ReadEinarTemplateFromBinaryPath(templateFolder string) (domain.EinarTemplate, error) {
+	return readEinarTemplate(templateFolder, 0, true)
+}
+
+// ReadCachedEinarTemplateFromBinaryPath reads the .einar.template.json of templateFolder
+// like ReadEinarTemplateFromBinaryPath, but fails with domain.ErrorTemplateNotFound
+// instead of cloning a parent template that is not cached.
+func ReadCachedEinarTemplateFromBinaryPath(templateFolder string) (domain.EinarTemplate, error) {
+	return readEinarTemplate(templateFolder, 0, false)
+}
+
+func readEinarTemplate(templateFolder string, depth int, fetch bool) (domain.EinarTemplate, error) {
 	// Construct the path to the JSON file relative to the binary
 	jsonFilePath := filepath.Join(templateFolder, ".einar.template.json")
 
 	// Read the JSON file content
-	jsonContentBytes, err := os.ReadFile(jsonFilePath)
+	jsonContentBytes, err := FS.ReadFile(jsonFilePath)
 	if err != nil {
 		return domain.EinarTemplate{}, fmt.Errorf("error reading JSON file: %v", err)
 	}
@@ -25,5 +41,43 @@ func ReadEinarTemplateFromBinaryPath(templateFolder string) (domain.EinarTemplat
 	if err != nil {
 		return domain.EinarTemplate{}, fmt.Errorf("error unmarshalling JSON file: %v", err)
 	}
-	return template, nil
+
+	if template.Extends == nil {
+		return template, nil
+	}
+	if depth >= maxExtendsDepth {
+		return domain.EinarTemplate{}, fmt.Errorf("template extends more than %d levels, check for cycles", maxExtendsDepth)
+	}
+
+	parentTemplate := domain.Template{URL: template.Extends.URL, Tag: template.Extends.Tag}
+	parentFolder, err := TemplateFolderPath(parentTemplate)
+	if err != nil {
+		return domain.EinarTemplate{}, err
+	}
+	if !IsTemplateCached(parentTemplate) {
+		if !fetch {
+			return domain.EinarTemplate{}, domain.Errorf(domain.ErrorTemplateNotFound, "parent template %s is not cached", parentTemplate)
+		}
+		if _, err := FetchTemplate(parentTemplate, "no-auth"); err != nil {
+			return domain.EinarTemplate{}, fmt.Errorf("error cloning parent template %s: %v", template.Extends.URL, err)
+		}
+	}
+
+	parent, err := readEinarTemplate(parentFolder, depth+1, fetch)
+	if err != nil {
+		return domain.EinarTemplate{}, fmt.Errorf("error reading parent template %s: %v", template.Extends.URL, err)
+	}
+
+	parentModule, parentErr := ReadTemplateModuleName(parentFolder)
+	childModule, childErr := ReadTemplateModuleName(templateFolder)
+	if parentErr == nil && childErr == nil && parentModule != childModule {
+		return domain.EinarTemplate{}, fmt.Errorf(
+			"template module %s must match the module %s of the template it extends", childModule, parentModule)
+	}
+
+	relativeParent, err := filepath.Rel(templateFolder, parentFolder)
+	if err != nil {
+		return domain.EinarTemplate{}, fmt.Errorf("error resolving parent template path: %v", err)
+	}
+	return ExtendEinarTemplate(parent, template, relativeParent), nil
 }
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+func TestPeekEinarCli(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantCode domain.ErrorCode
+		wantErr  string
+	}{
+		{
+			name:    "current schema version",
+			content: `{"schema_version": 1, "project": "app", "template": {"url": "https://github.com/x/tpl", "tag": "v1.0.0"}}`,
+		},
+		{
+			name:    "unversioned file with legacy fields",
+			content: `{"version": "${version}", "project": "app", "template": {"id": "d111", "url": "https://github.com/x/tpl"}}`,
+			wantErr: "run einar migrate-config",
+		},
+		{
+			name:    "unknown field",
+			content: `{"schema_version": 1, "project": "app", "componets": []}`,
+			wantErr: `unknown field "componets"`,
+		},
+		{
+			name:     "missing file",
+			wantCode: domain.ErrorNotInitialized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := FS
+			FS = NewMemFileSystem()
+			t.Cleanup(func() { FS = previous })
+			if tt.content != "" {
+				if err := FS.WriteFile(".einar.cli.json", []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			config, err := PeekEinarCli()
+			switch {
+			case tt.wantCode != "":
+				if domain.ErrorCodeOf(err) != tt.wantCode {
+					t.Fatalf("PeekEinarCli() error = %v, want %s", err, tt.wantCode)
+				}
+			case tt.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("PeekEinarCli() error = %v, want %q", err, tt.wantErr)
+				}
+			case err != nil || config.Project != "app":
+				t.Fatalf("PeekEinarCli() = %v, %v", config, err)
+			}
+			if content, _ := FS.ReadFile(".einar.cli.json"); string(content) != tt.content {
+				t.Errorf(".einar.cli.json = %s, want it left untouched", content)
+			}
+			if _, err := FS.Stat(".einar.cli.json.v0.bak"); err == nil {
+				t.Errorf("PeekEinarCli() wrote a backup")
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ReadGoMod parses the go.mod file found in projectPath.
+func ReadGoMod(projectPath string) (*modfile.File, error) {
+	modFilePath := filepath.Join(projectPath, "go.mod")
+	content, err := FS.ReadFile(modFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(modFilePath, content, nil)
+}
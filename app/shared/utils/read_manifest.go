@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// ReadManifest reads the manifest of generated files of the current project. Projects
+// without one have an empty manifest.
+func ReadManifest() (domain.Manifest, error) {
+	var manifest domain.Manifest
+	content, err := FS.ReadFile(filepath.FromSlash(domain.ManifestFile))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return manifest, fmt.Errorf("%s: %v", domain.ManifestFile, err)
+	}
+	return manifest, nil
+}
+
+// WriteManifest writes the manifest of generated files of the current project.
+func WriteManifest(manifest domain.Manifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.FromSlash(domain.ManifestFile)
+	if err := FS.MkdirAll(filepath.Dir(manifestPath), os.ModePerm); err != nil {
+		return err
+	}
+	return FS.WriteFile(manifestPath, content, 0644)
+}
+
+// HashFile returns the hex encoded SHA-256 of the content of filePath.
+func HashFile(filePath string) (string, error) {
+	content, err := FS.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -2,8 +2,8 @@ package utils
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
-	"os"
 	"strings"
 )
 
@@ -11,15 +11,14 @@ func ReadTemplateModuleName(templatePath string) (string, error) {
 	// Construir la ruta completa al archivo go.mod
 	modFilePath := templatePath + "/go.mod"
 
-	// Abrir el archivo go.mod
-	file, err := os.Open(modFilePath)
+	// Leer el archivo go.mod
+	content, err := FS.ReadFile(modFilePath)
 	if err != nil {
 		return "", err
 	}
-	defer file.Close()
 
 	// Leer el archivo línea por línea
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.HasPrefix(line, "module ") {
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// RemoveImportStatement removes the blank import of importPath from the Go file at filePath.
+// The file is left untouched when the import is not present.
+func RemoveImportStatement(filePath, importPath string) error {
+	importPath = strings.ReplaceAll(importPath, "\\", "/")
+	src, err := FS.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	if !astutil.DeleteNamedImport(fset, file, "_", importPath) {
+		return nil
+	}
+	return writeGoFile(filePath, fset, file)
+}
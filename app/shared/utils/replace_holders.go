@@ -10,8 +10,11 @@ func replacePlaceholders(filename string, placeholders []string, values []string
 	if len(placeholders) != len(values) {
 		return errors.New("placeholders and values arrays must have the same length")
 	}
+	if len(placeholders) == 0 {
+		return nil
+	}
 	// Read the file content
-	content, err := os.ReadFile(filename)
+	content, err := FS.ReadFile(filename)
 	if err != nil {
 		return err
 	}
@@ -21,11 +24,14 @@ func replacePlaceholders(filename string, placeholders []string, values []string
 
 	// Replace each placeholder with the corresponding value
 	for i, placeholder := range placeholders {
+		if count := strings.Count(updatedContent, placeholder); count > 0 {
+			Debug("placeholder replaced", "file", filename, "placeholder", placeholder, "value", values[i], "count", count)
+		}
 		updatedContent = strings.ReplaceAll(updatedContent, placeholder, values[i])
 	}
 
 	// Write the updated content back to the file
-	err = os.WriteFile(filename, []byte(updatedContent), os.ModePerm)
+	err = FS.WriteFile(filename, []byte(updatedContent), os.ModePerm)
 	if err != nil {
 		return err
 	}
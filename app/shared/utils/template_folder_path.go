@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+)
+
+// LocalTemplateScheme prefixes template URLs that point to a template folder on disk.
+const LocalTemplateScheme = "file://"
+
+// TemplateFolderPath returns the folder holding template. Git templates are cached in the
+// binary path under their tag or pinned commit while local templates are used in place.
+func TemplateFolderPath(template domain.Template) (string, error) {
+	if strings.HasPrefix(template.URL, LocalTemplateScheme) && !IsArchive(template.URL) {
+		path := filepath.FromSlash(strings.TrimPrefix(template.URL, LocalTemplateScheme))
+		Debug("template folder path computed", "template", template.String(), "path", path)
+		return path, nil
+	}
+	// the repository folder is resolved first, archive extensions are only trimmed from the
+	// end of the URL
+	path, err := GetTemplateFolderPath(template.URL)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case IsArchive(template.URL):
+		if path, err = CacheTagPath(path, template.Tag); err != nil {
+			return "", err
+		}
+	case template.CacheFolder() != "":
+		path = filepath.Join(path, template.CacheFolder())
+	}
+	Debug("template folder path computed", "template", template.String(), "path", path)
+	return path, nil
+}
@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"golang.org/x/mod/semver"
+)
+
+// templateMirrorFolder is the bare repository, next to the cached tags of a template, that
+// keeps the objects fetched so far so other tags are fetched incrementally.
+const templateMirrorFolder = ".mirror"
+
+// templateMirror is the bare mirror of a template repository, locked while in use.
+type templateMirror struct {
+	path   string
+	url    string
+	repo   *git.Repository
+	auth   *http.BasicAuth
+	unlock func()
+}
+
+// openTemplateMirror opens the mirror of repositoryUrl stored in targetPath, creating it
+// when missing, and locks it until close is called.
+func openTemplateMirror(repositoryUrl, userCreds, targetPath string) (*templateMirror, error) {
+	var auth *http.BasicAuth
+	if userCreds != "no-auth" {
+		user, token, err := SplitCredentials(userCreds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse user credentials: %v", err)
+		}
+		auth = &http.BasicAuth{Username: user, Password: token}
+	}
+
+	mirrorPath := filepath.Join(targetPath, templateMirrorFolder)
+	if err := os.MkdirAll(targetPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	unlock, err := LockCacheEntry(mirrorPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror := &templateMirror{path: mirrorPath, url: repositoryUrl, auth: auth, unlock: unlock}
+	mirror.repo, err = git.PlainOpen(mirrorPath)
+	if err == nil {
+		err = setMirrorRemote(mirror.repo, repositoryUrl)
+	} else {
+		err = mirror.reset()
+	}
+	if err != nil {
+		unlock()
+		return nil, fmt.Errorf("failed to open template mirror %s: %v", mirrorPath, err)
+	}
+	return mirror, nil
+}
+
+// reset replaces the mirror with an empty repository. Shallow mirrors are reset before
+// fetching full history, since the remote assumes the ancestors of shallow commits exist.
+func (m *templateMirror) reset() error {
+	if err := os.RemoveAll(m.path); err != nil {
+		return err
+	}
+	repo, err := git.PlainInit(m.path, true)
+	if err != nil {
+		return err
+	}
+	m.repo = repo
+	return setMirrorRemote(repo, m.url)
+}
+
+func setMirrorRemote(repo *git.Repository, repositoryUrl string) error {
+	if remote, err := repo.Remote(git.DefaultRemoteName); err == nil {
+		if len(remote.Config().URLs) > 0 && remote.Config().URLs[0] == repositoryUrl {
+			return nil
+		}
+		if err := repo.DeleteRemote(git.DefaultRemoteName); err != nil {
+			return err
+		}
+	}
+	_, err := repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repositoryUrl}})
+	return err
+}
+
+func (m *templateMirror) close() {
+	m.unlock()
+}
+
+// fetch fetches refSpecs into the mirror, only the commits they point to when depth is 1.
+func (m *templateMirror) fetch(depth int, refSpecs ...config.RefSpec) error {
+	err := m.repo.Fetch(&git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   refSpecs,
+		Depth:      depth,
+		Tags:       git.NoTags,
+		Auth:       m.auth,
+		Progress:   LogWriter(),
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// latestTag lists the tags of the remote repository and returns the highest semantic
+// version, or the last tag in lexical order when no tag is a semantic version.
+func (m *templateMirror) latestTag() (string, error) {
+	remote, err := m.repo.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return "", err
+	}
+	refs, err := remote.List(&git.ListOptions{Auth: m.auth})
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %v", err)
+	}
+
+	var tags []string
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags = append(tags, ref.Name().Short())
+		}
+	}
+	if len(tags) == 0 {
+		return "", errors.New("template repository has no tags")
+	}
+	sort.Strings(tags)
+
+	latest := ""
+	for _, tag := range tags {
+		if semver.IsValid(tag) && (latest == "" || semver.Compare(tag, latest) > 0) {
+			latest = tag
+		}
+	}
+	if latest == "" {
+		latest = tags[len(tags)-1]
+	}
+	return latest, nil
+}
+
+// writeTree writes the files of the commit hash points to into dir, without any VCS
+// metadata.
+func (m *templateMirror) writeTree(hash plumbing.Hash, dir string) error {
+	commit, err := m.repo.CommitObject(hash)
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	return tree.Files().ForEach(func(file *object.File) error {
+		filePath := filepath.Join(dir, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			return err
+		}
+		if file.Mode == filemode.Symlink {
+			target, err := file.Contents()
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, filePath)
+		}
+
+		perm := os.FileMode(0644)
+		if file.Mode == filemode.Executable {
+			perm = 0755
+		}
+		reader, err := file.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		out, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		_, err = io.Copy(out, reader)
+		return err
+	})
+}
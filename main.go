@@ -1,11 +1,16 @@
 package main
 
 import (
+	"os"
+
 	_ "github.com/Ignaciojeria/einar/app/adapter/in/cli"
 	_ "github.com/Ignaciojeria/einar/app/adapter/in/controller"
+	"github.com/Ignaciojeria/einar/app/domain"
 	"github.com/Ignaciojeria/einar/app/shared/archetype/cmd"
 )
 
 func main() {
-	cmd.RootCmd.Execute()
+	if err := cmd.RootCmd.Execute(); err != nil {
+		os.Exit(domain.ExitCode(err))
+	}
 }
@@ -0,0 +1,170 @@
+// Package einar drives the einar generator from Go programs, without going through the
+// command line.
+//
+// The generator works on the current working directory, so every Project call enters the
+// project folder for its duration and calls are serialized across the process.
+package einar
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/Ignaciojeria/einar/app/business"
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+// ChangeSet lists the files, imports, installations and components a call added.
+type ChangeSet = domain.ChangeSet
+
+// Options configures a Project.
+type Options struct {
+	// Logger receives the messages einar logs on the command line. They are discarded
+	// when nil.
+	Logger *slog.Logger
+}
+
+// InstallOptions selects the installation added by Project.Install.
+type InstallOptions struct {
+	// Installation is the name of an installation command of the project templates.
+	Installation string
+}
+
+// GenerateOptions selects the component added by Project.Generate and Project.Plan.
+type GenerateOptions struct {
+	// Kind is the component kind, as listed by einar list kinds.
+	Kind string
+	// Name is the component name. It is converted to kebab case like on the command line.
+	Name string
+}
+
+// Project is an einar project, the folder holding its .einar.cli.json file.
+type Project struct {
+	dir    string
+	logger *slog.Logger
+}
+
+// workdir guards the process working directory while a Project call runs.
+var workdir sync.Mutex
+
+// Open returns the project holding dir, which is either its root or a folder below it.
+// Folders outside einar projects are reported with domain.ErrorNotInitialized. Open does
+// not write the project, so a .einar.cli.json of an older schema version is reported
+// until einar migrate-config is run.
+func Open(dir string, options Options) (*Project, error) {
+	root, ok := utils.FindUp(dir, ".einar.cli.json")
+	if !ok {
+		return nil, domain.Errorf(domain.ErrorNotInitialized, "no einar project found at %s or above it", dir)
+	}
+	project := &Project{dir: root, logger: options.Logger}
+	if project.logger == nil {
+		project.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	err := project.run(func() error {
+		_, err := project.config(utils.PeekEinarCli)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// Dir returns the absolute path of the project root.
+func (p *Project) Dir() string {
+	return p.dir
+}
+
+// Config returns the current content of the .einar.cli.json file of the project, without
+// migrating it.
+func (p *Project) Config() (domain.EinarCli, error) {
+	var config domain.EinarCli
+	err := p.run(func() (err error) {
+		config, err = p.config(utils.PeekEinarCli)
+		return err
+	})
+	return config, err
+}
+
+// Install adds an installation of the project templates, like einar install.
+func (p *Project) Install(ctx context.Context, options InstallOptions) (ChangeSet, error) {
+	var changes ChangeSet
+	err := p.run(func() error {
+		config, err := p.config(utils.ReadEinarCli)
+		if err != nil {
+			return err
+		}
+		if config.IsInstalled(options.Installation) {
+			return domain.Errorf(domain.ErrorAlreadyExists, "installation %s already added", options.Installation)
+		}
+		changes, err = business.EinarInstall(ctx, config.Project, options.Installation)
+		return err
+	})
+	return changes, err
+}
+
+// Generate adds a component to the project, like einar generate.
+func (p *Project) Generate(ctx context.Context, options GenerateOptions) (ChangeSet, error) {
+	var changes ChangeSet
+	err := p.run(func() error {
+		config, err := p.config(utils.ReadEinarCli)
+		if err != nil {
+			return err
+		}
+		changes, err = business.EinarGenerate(ctx, config.Project, options.Kind, utils.ConvertStringCase(options.Name, "kebab"))
+		return err
+	})
+	return changes, err
+}
+
+// Plan returns the changes Generate would make, without touching the project. The
+// component is generated in a scratch copy of the project, which is removed afterwards.
+func (p *Project) Plan(ctx context.Context, options GenerateOptions) (ChangeSet, error) {
+	scratch, err := os.MkdirTemp("", "einar-plan-")
+	if err != nil {
+		return ChangeSet{}, err
+	}
+	defer os.RemoveAll(scratch)
+
+	if err := copyProject(p.dir, scratch); err != nil {
+		return ChangeSet{}, err
+	}
+	return (&Project{dir: scratch, logger: p.logger}).Generate(ctx, options)
+}
+
+// run calls fn inside the project folder, with the progress messages sent to the project
+// logger.
+func (p *Project) run(fn func() error) error {
+	workdir.Lock()
+	defer workdir.Unlock()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(p.dir); err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+
+	utils.SetLogger(p.logger)
+	defer utils.SetLogger(nil)
+	return fn()
+}
+
+// config reads the .einar.cli.json file of the project with read, utils.PeekEinarCli for
+// calls that must not write the project and utils.ReadEinarCli for those that migrate it
+// before changing it. It must run inside the project folder.
+func (p *Project) config(read func() (domain.EinarCli, error)) (domain.EinarCli, error) {
+	config, err := read()
+	if err != nil {
+		return config, err
+	}
+	if config.Project == "${project}" {
+		return config, domain.Errorf(domain.ErrorNotInitialized, "%s is an einar template, not a project", p.dir)
+	}
+	return config, nil
+}
@@ -0,0 +1,107 @@
+package einar
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Ignaciojeria/einar/app/domain"
+	"github.com/Ignaciojeria/einar/app/shared/utils"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProject(t *testing.T) {
+	templateDir, projectDir := t.TempDir(), t.TempDir()
+	writeTestFiles(t, templateDir, map[string]string{
+		".einar.template.json": `{"component_commands": [{"kind": "note", "depends_on": [""], "files": [
+			{"source_file": "note.go", "destination_dir": "app/notes"}]}]}`,
+		"go.mod":  "module github.com/acme/template\n",
+		"note.go": "package notes\n",
+	})
+	writeTestFiles(t, projectDir, map[string]string{
+		".einar.cli.json": `{"schema_version": 1, "project": "demo",
+			"template": {"url": "` + utils.LocalTemplateScheme + filepath.ToSlash(templateDir) + `", "tag": ""}}`,
+		"go.mod":      "module demo\n",
+		"app/main.go": "package main\n",
+	})
+	ctx := context.Background()
+
+	if _, err := Open(t.TempDir(), Options{}); domain.ErrorCodeOf(err) != domain.ErrorNotInitialized {
+		t.Fatalf("Open() outside a project error = %v, want %s", err, domain.ErrorNotInitialized)
+	}
+
+	project, err := Open(filepath.Join(projectDir, "app"), Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if project.Dir() != projectDir {
+		t.Fatalf("Dir() = %s, want %s", project.Dir(), projectDir)
+	}
+
+	generated := filepath.Join(projectDir, "app", "notes", "first_note.go")
+	plan, err := project.Plan(ctx, GenerateOptions{Kind: "note", Name: "FirstNote"})
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(plan.Files) != 1 || plan.Files[0] != "app/notes/first_note.go" {
+		t.Fatalf("Plan() files = %v, want [app/notes/first_note.go]", plan.Files)
+	}
+	if _, err := os.Stat(generated); !os.IsNotExist(err) {
+		t.Fatalf("Plan() wrote %s", generated)
+	}
+
+	changes, err := project.Generate(ctx, GenerateOptions{Kind: "note", Name: "FirstNote"})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(changes.Files) != 1 || changes.Files[0] != plan.Files[0] {
+		t.Fatalf("Generate() files = %v, want %v", changes.Files, plan.Files)
+	}
+	if _, err := os.Stat(generated); err != nil {
+		t.Fatalf("Generate() did not write %s: %v", generated, err)
+	}
+	config, err := project.Config()
+	if err != nil || len(config.Components) != 1 {
+		t.Fatalf("Config() components = %v, error = %v", config.Components, err)
+	}
+
+	_, err = project.Generate(ctx, GenerateOptions{Kind: "note", Name: "first-note"})
+	if domain.ErrorCodeOf(err) != domain.ErrorAlreadyExists {
+		t.Fatalf("Generate() twice error = %v, want %s", err, domain.ErrorAlreadyExists)
+	}
+	_, err = project.Generate(ctx, GenerateOptions{Kind: "missing", Name: "x"})
+	if domain.ErrorCodeOf(err) != domain.ErrorKindUnknown {
+		t.Fatalf("Generate() unknown kind error = %v, want %s", err, domain.ErrorKindUnknown)
+	}
+}
+
+func TestOpenDoesNotMigrate(t *testing.T) {
+	projectDir := t.TempDir()
+	legacy := `{"project": "demo", "componets": []}`
+	writeTestFiles(t, projectDir, map[string]string{".einar.cli.json": legacy})
+
+	if _, err := Open(projectDir, Options{}); err == nil || !strings.Contains(err.Error(), "migrate-config") {
+		t.Errorf("Open() error = %v, want einar migrate-config suggested", err)
+	}
+	content, err := os.ReadFile(filepath.Join(projectDir, ".einar.cli.json"))
+	if err != nil || string(content) != legacy {
+		t.Errorf(".einar.cli.json = %s, %v, want it left as it was", content, err)
+	}
+	if entries, _ := os.ReadDir(projectDir); len(entries) != 1 {
+		t.Errorf("project folder = %v, want no backup written", entries)
+	}
+}